@@ -1,16 +1,130 @@
 package applications
 
 import (
+	"errors"
+	"io"
+	"time"
+
+	databases "github.com/steve-care-software/databases/applications"
 	"github.com/steve-care-software/databases/domain/references"
 	"github.com/steve-care-software/libs/cryptography/hash"
 )
 
+const (
+	// StatusNeverExisted represents a resource that was never written
+	StatusNeverExisted Status = iota
+
+	// StatusErased represents a resource that was written and later erased
+	StatusErased
+
+	// StatusPresent represents a resource that is currently present
+	StatusPresent
+)
+
+// ErrAlreadyExists is returned when writing a resource whose kind and hash is already active or staged on the context,
+// an erased resource stays active until its erasure is committed, so it can only be written again after that commit,
+// and the staged resources are only forgotten by a commit or by the application's Cancel, not by a Cancel on the pointer database
+var ErrAlreadyExists = errors.New("the resource already exists")
+
 // Application represents the database application
 type Application interface {
+	NewByHash(seed hash.Hash) (string, error)
+	RegisterValidator(kind uint, fn func(data []byte) error)
 	List(context uint, kind uint) ([]hash.Hash, error)
+	ListRecent(context uint, kind uint, limit uint) ([]references.ContentKey, error)
+	MissingFrom(context uint, kind uint, candidates []hash.Hash) ([]hash.Hash, error)
+	ExtraIn(context uint, kind uint, candidates []hash.Hash) ([]hash.Hash, error)
+	FindByPrefix(context uint, kind uint, prefix string) ([]references.ContentKey, error)
+	ResolvePrefix(context uint, kind uint, prefix string) (references.ContentKey, error)
+	RootHash(context uint, kind uint) (hash.Hash, error)
+	Warm(context uint, kind uint) error
+	Length(context uint, kind uint, hash hash.Hash) (uint, error)
+	Stat(context uint, kind uint, hash hash.Hash) (*Stat, error)
+	StatAll(context uint, kind uint, hashes []hash.Hash) ([]Stat, error)
 	Read(context uint, kind uint, hash hash.Hash) ([]byte, error)
+	ReadByKey(context uint, contentKey references.ContentKey) ([]byte, error)
+	ReadWithKey(context uint, kind uint, hash hash.Hash) ([]byte, references.ContentKey, error)
+	ReadConsistent(context uint, kind uint, hashes []hash.Hash) ([][]byte, error)
 	ReadAll(context uint, kind uint, hashes []hash.Hash) ([][]byte, error)
+	ReadKind(context uint, kind uint) (map[string][]byte, error)
 	Erase(context uint, kind uint, hash hash.Hash) error
+	Cancel(context uint) error
 	EraseAll(context uint, kind uint, hashes []hash.Hash) error
+	WriteReader(context uint, kind uint, reader io.Reader) (hash.Hash, error)
+	SyncFrom(context uint, remote RemoteReader) error
+	ExportKind(context uint, kind uint, writer io.Writer) error
+	ImportKind(context uint, reader io.Reader) error
+	NewBatch(context uint) Batch
+	Verify(context uint, kind uint) error
+	Orphans(context uint, kind uint) ([]references.ContentKey, error)
 	Commit(context uint, hash hash.Hash) (references.Commit, error)
+	CommitTimeRange(context uint) (time.Time, time.Time, error)
+	CommitDepth(context uint, commit hash.Hash) (uint, error)
+	CommitExists(context uint, commit hash.Hash) (bool, error)
+	CommitsByHashes(context uint, hashes []hash.Hash) ([]references.Commit, error)
+	KeyHistory(context uint, kind uint, hash hash.Hash) ([]references.Commit, error)
+	KindLatestCommit(context uint, kind uint) (references.Commit, error)
+	ReadStatus(context uint, kind uint, hash hash.Hash) (Status, references.Commit, error)
+	DeletedBy(context uint, kind uint, hash hash.Hash) (references.Commit, error)
+	CommitIterator(context uint) (func() (references.Commit, bool, error), error)
+}
+
+// Status represents the lifecycle status of a resource
+type Status uint8
+
+// Stat represents the metadata of a resource
+type Stat struct {
+	Kind      uint
+	Hash      hash.Hash
+	Length    uint
+	Commit    hash.Hash
+	CreatedOn time.Time
+}
+
+// Batcher represents a context whose staged resources are committed periodically,
+// the commits run in the background without any lock shared with the caller, so a context wrapped by a Batcher must only be used through it
+type Batcher interface {
+	Write(kind uint, hash hash.Hash, data []byte) error
+	Erase(contentKey references.ContentKey) error
+	Flush() error
+	Close() error
+}
+
+// RemoteReader represents a read-only database to synchronize from
+type RemoteReader interface {
+	Kinds() ([]uint, error)
+	RootHash(kind uint) (hash.Hash, error)
+	List(kind uint) ([]hash.Hash, error)
+	Read(kind uint, hash hash.Hash) ([]byte, error)
+}
+
+// Batch represents resources to write and erase in a single commit
+type Batch interface {
+	Put(kind uint, hash hash.Hash, data []byte)
+	Delete(kind uint, hash hash.Hash)
+	Apply() error
+	Discard()
+}
+
+// Replayer represents a replayer of recorded operations
+type Replayer interface {
+	Replay(name string, reader io.Reader) error
+}
+
+// CommitHook represents a function executed after a successful commit, with the content keys it inserted and deleted
+type CommitHook func(context uint, commit references.Commit, inserted []references.ContentKey, deleted []references.ContentKey) error
+
+// Notifier represents a pointer database that executes hooks after its commits
+type Notifier interface {
+	databases.Application
+	OnCommit(hook CommitHook)
+}
+
+// WriteHook represents a function executed on a staged write right before it is committed, the returned data is persisted under the original hash
+type WriteHook func(kind uint, hash hash.Hash, data []byte) ([]byte, error)
+
+// Interceptor represents a pointer database that executes hooks on its writes before committing them
+type Interceptor interface {
+	databases.Application
+	OnBeforeWrite(hook WriteHook)
 }
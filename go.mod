@@ -3,7 +3,8 @@ module github.com/steve-care-software/hashdb
 go 1.19
 
 require (
-	github.com/juju/fslock v0.0.0-20160525022230-4d5c94c67b4b // indirect
-	github.com/steve-care-software/databases v0.0.0-20230317225037-cdb3618c31b0 // indirect
-	github.com/steve-care-software/libs v0.0.0-20230312132714-485fdb38680d // indirect
+	github.com/steve-care-software/databases v0.0.0-20230317225037-cdb3618c31b0
+	github.com/steve-care-software/libs v0.0.0-20230312132714-485fdb38680d
 )
+
+require github.com/juju/fslock v0.0.0-20160525022230-4d5c94c67b4b // indirect
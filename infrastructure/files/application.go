@@ -1,29 +1,80 @@
 package files
 
 import (
+	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	databases "github.com/steve-care-software/databases/applications"
 	"github.com/steve-care-software/databases/domain/references"
 	hashdb "github.com/steve-care-software/hashdb/applications"
 	"github.com/steve-care-software/libs/cryptography/hash"
+	"github.com/steve-care-software/libs/cryptography/trees"
 )
 
 type application struct {
-	pointerDB databases.Application
+	hashAdapter      hash.Adapter
+	hashTreeBuilder  trees.Builder
+	hashTreeAdapter  trees.Adapter
+	commitBuilder    references.CommitBuilder
+	pointerDB        databases.Application
+	contentCacheSize uint
+	contentCaches    map[uint]*contentCache
+	cachesMutex      *sync.Mutex
+	staged           map[uint]*stagedResources
+	stagedMutex      *sync.Mutex
+	validators       map[uint]func(data []byte) error
 }
 
 func createApplication(
+	hashAdapter hash.Adapter,
+	hashTreeBuilder trees.Builder,
+	hashTreeAdapter trees.Adapter,
+	commitBuilder references.CommitBuilder,
 	pointerDB databases.Application,
+	contentCacheSize uint,
 ) hashdb.Application {
 	out := application{
-		pointerDB: pointerDB,
+		hashAdapter:      hashAdapter,
+		hashTreeBuilder:  hashTreeBuilder,
+		hashTreeAdapter:  hashTreeAdapter,
+		commitBuilder:    commitBuilder,
+		pointerDB:        pointerDB,
+		contentCacheSize: contentCacheSize,
+		contentCaches:    map[uint]*contentCache{},
+		cachesMutex:      &sync.Mutex{},
+		staged:           map[uint]*stagedResources{},
+		stagedMutex:      &sync.Mutex{},
+		validators:       map[uint]func(data []byte) error{},
 	}
 
 	return &out
 }
 
+// NewByHash creates a database whose name is derived from the seed hash and returns that name
+func (app *application) NewByHash(seed hash.Hash) (string, error) {
+	// the name is the seed itself, so Exists and Open can locate the database using seed.String():
+	name := seed.String()
+	err := app.pointerDB.New(name)
+	if err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
+
+// RegisterValidator registers a function that validates the data written to a kind, before it is staged
+func (app *application) RegisterValidator(kind uint, fn func(data []byte) error) {
+	app.validators[kind] = fn
+}
+
 // List returns the hashes by kind
 func (app *application) List(context uint, kind uint) ([]hash.Hash, error) {
 	keys, err := app.pointerDB.ContentKeys(context, kind)
@@ -40,14 +91,329 @@ func (app *application) List(context uint, kind uint) ([]hash.Hash, error) {
 	return hashes, nil
 }
 
+// ListRecent returns up to limit content keys of a kind, from the most recently committed
+func (app *application) ListRecent(context uint, kind uint, limit uint) ([]references.ContentKey, error) {
+	keys, err := app.pointerDB.ContentKeys(context, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	commits, err := app.pointerDB.Commits(context)
+	if err != nil {
+		return nil, err
+	}
+
+	list := keys.List()
+	createdOn := map[string]int64{}
+	for _, oneContentKey := range list {
+		commit, err := commits.Fetch(oneContentKey.Commit())
+		if err != nil {
+			return nil, err
+		}
+
+		createdOn[oneContentKey.Hash().String()] = commit.CreatedOn().UnixNano()
+	}
+
+	output := make([]references.ContentKey, len(list))
+	copy(output, list)
+	sort.SliceStable(output, func(i, j int) bool {
+		return createdOn[output[i].Hash().String()] > createdOn[output[j].Hash().String()]
+	})
+
+	if uint(len(output)) > limit {
+		output = output[:limit]
+	}
+
+	return output, nil
+}
+
+// MissingFrom returns the candidate hashes that are not present in the kind, a kind without content is considered empty
+func (app *application) MissingFrom(context uint, kind uint, candidates []hash.Hash) ([]hash.Hash, error) {
+	keys, err := app.retrieveContentKeysOrEmpty(context, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	output := []hash.Hash{}
+	for _, oneCandidate := range candidates {
+		if keys != nil {
+			_, err := keys.Fetch(kind, oneCandidate)
+			if err == nil {
+				continue
+			}
+		}
+
+		output = append(output, oneCandidate)
+	}
+
+	return output, nil
+}
+
+// ExtraIn returns the hashes of the kind that are not present in the candidates, a kind without content is considered empty
+func (app *application) ExtraIn(context uint, kind uint, candidates []hash.Hash) ([]hash.Hash, error) {
+	keys, err := app.retrieveContentKeysOrEmpty(context, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	output := []hash.Hash{}
+	if keys == nil {
+		return output, nil
+	}
+
+	candidatesMap := map[string]bool{}
+	for _, oneCandidate := range candidates {
+		candidatesMap[oneCandidate.String()] = true
+	}
+
+	list := keys.List()
+	for _, oneContentKey := range list {
+		oneHash := oneContentKey.Hash()
+		if _, ok := candidatesMap[oneHash.String()]; ok {
+			continue
+		}
+
+		output = append(output, oneHash)
+	}
+
+	return output, nil
+}
+
+// FindByPrefix returns the content keys whose hash begins with the given prefix
+func (app *application) FindByPrefix(context uint, kind uint, prefix string) ([]references.ContentKey, error) {
+	keys, err := app.pointerDB.ContentKeys(context, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix = strings.ToLower(prefix)
+	output := []references.ContentKey{}
+	list := keys.List()
+	for _, oneContentKey := range list {
+		if !strings.HasPrefix(oneContentKey.Hash().String(), prefix) {
+			continue
+		}
+
+		output = append(output, oneContentKey)
+	}
+
+	return output, nil
+}
+
+// ResolvePrefix returns the only content key whose hash begins with the given prefix
+func (app *application) ResolvePrefix(context uint, kind uint, prefix string) (references.ContentKey, error) {
+	list, err := app.FindByPrefix(context, kind, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) <= 0 {
+		str := fmt.Sprintf("there is no resource (kind: %d) whose hash begins with the prefix: %s", kind, prefix)
+		return nil, errors.New(str)
+	}
+
+	if len(list) > 1 {
+		str := fmt.Sprintf("the prefix (%s) is ambiguous because %d resources (kind: %d) have an hash that begins with it", prefix, len(list), kind)
+		return nil, errors.New(str)
+	}
+
+	return list[0], nil
+}
+
+// RootHash returns the head of the hashtree built from the sorted hashes of a kind
+func (app *application) RootHash(context uint, kind uint) (hash.Hash, error) {
+	hashes, err := app.List(context, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	// sort the hashes so that the root does not depend on the insertion order:
+	sort.Slice(hashes, func(i, j int) bool {
+		return hashes[i].String() < hashes[j].String()
+	})
+
+	blocks := [][]byte{}
+	for _, oneHash := range hashes {
+		blocks = append(blocks, oneHash.Bytes())
+	}
+
+	tree, err := app.hashTreeBuilder.Create().WithBlocks(blocks).Now()
+	if err != nil {
+		return nil, err
+	}
+
+	return tree.Head(), nil
+}
+
+// Warm reads the content of a kind into the content cache, as long as it fits its budget
+func (app *application) Warm(context uint, kind uint) error {
+	if app.contentCacheSize <= 0 {
+		return nil
+	}
+
+	keys, err := app.pointerDB.ContentKeys(context, kind)
+	if err != nil {
+		return err
+	}
+
+	total := uint(0)
+	list := keys.List()
+	for _, oneContentKey := range list {
+		pointer := oneContentKey.Content()
+		if total+pointer.Length() > app.contentCacheSize {
+			continue
+		}
+
+		_, err := app.readPointer(context, pointer)
+		if err != nil {
+			return err
+		}
+
+		total += pointer.Length()
+	}
+
+	return nil
+}
+
+// Length returns the length of the content of an hash, without reading it
+func (app *application) Length(context uint, kind uint, hash hash.Hash) (uint, error) {
+	contentKey, err := app.retrieveActiveContentKeyByHash(context, kind, hash)
+	if err != nil {
+		return 0, err
+	}
+
+	return contentKey.Content().Length(), nil
+}
+
+// Stat returns the metadata of a resource, without reading its content
+func (app *application) Stat(context uint, kind uint, hash hash.Hash) (*hashdb.Stat, error) {
+	keys, err := app.pointerDB.ContentKeys(context, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	commits, err := app.pointerDB.Commits(context)
+	if err != nil {
+		return nil, err
+	}
+
+	return app.stat(keys, commits, kind, hash)
+}
+
+// StatAll returns the metadata of resources in the order of the hashes, without reading their content
+func (app *application) StatAll(context uint, kind uint, hashes []hash.Hash) ([]hashdb.Stat, error) {
+	keys, err := app.pointerDB.ContentKeys(context, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	commits, err := app.pointerDB.Commits(context)
+	if err != nil {
+		return nil, err
+	}
+
+	output := []hashdb.Stat{}
+	for _, oneHash := range hashes {
+		pStat, err := app.stat(keys, commits, kind, oneHash)
+		if err != nil {
+			return nil, err
+		}
+
+		output = append(output, *pStat)
+	}
+
+	return output, nil
+}
+
+func (app *application) stat(keys references.ContentKeys, commits references.Commits, kind uint, hash hash.Hash) (*hashdb.Stat, error) {
+	contentKey, err := keys.Fetch(kind, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := commits.Fetch(contentKey.Commit())
+	if err != nil {
+		return nil, err
+	}
+
+	return &hashdb.Stat{
+		Kind:      contentKey.Kind(),
+		Hash:      contentKey.Hash(),
+		Length:    contentKey.Content().Length(),
+		Commit:    commit.Hash(),
+		CreatedOn: commit.CreatedOn(),
+	}, nil
+}
+
 // Read reads content by hash
 func (app *application) Read(context uint, kind uint, hash hash.Hash) ([]byte, error) {
+	data, _, err := app.ReadWithKey(context, kind, hash)
+	return data, err
+}
+
+// ReadByKey reads content by content key
+func (app *application) ReadByKey(context uint, contentKey references.ContentKey) ([]byte, error) {
+	return app.readPointer(context, contentKey.Content())
+}
+
+// ReadWithKey reads content by hash and returns it along with its content key
+func (app *application) ReadWithKey(context uint, kind uint, hash hash.Hash) ([]byte, references.ContentKey, error) {
 	contentKey, err := app.retrieveActiveContentKeyByHash(context, kind, hash)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	data, err := app.ReadByKey(context, contentKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return data, contentKey, nil
+}
+
+// ReadConsistent reads the content of many hashes as of a single commit, in the same order,
+// the reads are done again when a commit lands meanwhile, so the result reflects the latest commit rather than the one at the time of the call
+func (app *application) ReadConsistent(context uint, kind uint, hashes []hash.Hash) ([][]byte, error) {
+	for i := 0; i < readConsistentMaxAttempts; i++ {
+		before, err := app.pointerDB.Commits(context)
+		if err != nil {
+			return nil, err
+		}
+
+		// the content keys are fetched once, so every hash is resolved against the same reference:
+		contentKeys, err := app.pointerDB.ContentKeys(context, kind)
+		if err != nil {
+			return nil, err
+		}
+
+		output := [][]byte{}
+		for _, oneHash := range hashes {
+			contentKey, err := contentKeys.Fetch(kind, oneHash)
+			if err != nil {
+				return nil, err
+			}
+
+			data, err := app.ReadByKey(context, contentKey)
+			if err != nil {
+				return nil, err
+			}
+
+			output = append(output, data)
+		}
+
+		// a commit that landed during the reads invalidates the pointers, so the reads are done again:
+		after, err := app.pointerDB.Commits(context)
+		if err != nil {
+			return nil, err
+		}
+
+		if before.Latest().Hash().Compare(after.Latest().Hash()) {
+			return output, nil
+		}
 	}
 
-	return app.pointerDB.Read(context, contentKey.Content())
+	str := fmt.Sprintf("the content of the kind (%d) could not be read consistently because a commit landed during each of the %d attempts", kind, readConsistentMaxAttempts)
+	return nil, errors.New(str)
 }
 
 // ReadAll reads content by hashes
@@ -65,6 +431,27 @@ func (app *application) ReadAll(context uint, kind uint, hashes []hash.Hash) ([]
 	return output, nil
 }
 
+// ReadKind reads the content of every resource of a kind, keyed by hash
+func (app *application) ReadKind(context uint, kind uint) (map[string][]byte, error) {
+	keys, err := app.pointerDB.ContentKeys(context, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	output := map[string][]byte{}
+	list := keys.List()
+	for _, oneContentKey := range list {
+		content, err := app.readPointer(context, oneContentKey.Content())
+		if err != nil {
+			return nil, err
+		}
+
+		output[oneContentKey.Hash().String()] = content
+	}
+
+	return output, nil
+}
+
 // Erase erases by hash
 func (app *application) Erase(context uint, kind uint, hash hash.Hash) error {
 	// retrieve the content key:
@@ -76,6 +463,19 @@ func (app *application) Erase(context uint, kind uint, hash hash.Hash) error {
 	return app.pointerDB.Erase(context, contentKey)
 }
 
+// Cancel cancels the resources staged on a context
+func (app *application) Cancel(context uint) error {
+	err := app.pointerDB.Cancel(context)
+	if err != nil {
+		return err
+	}
+
+	app.stagedMutex.Lock()
+	defer app.stagedMutex.Unlock()
+	delete(app.staged, context)
+	return nil
+}
+
 // EraseAll erases by hashes
 func (app *application) EraseAll(context uint, kind uint, hashes []hash.Hash) error {
 	for _, oneHash := range hashes {
@@ -88,14 +488,732 @@ func (app *application) EraseAll(context uint, kind uint, hashes []hash.Hash) er
 	return nil
 }
 
-// Commit returns the commit by hash
-func (app *application) Commit(context uint, hash hash.Hash) (references.Commit, error) {
-	commits, err := app.pointerDB.Commits(context)
+// WriteReader reads the whole content of a reader in memory, then writes it and returns its computed hash, along with ErrAlreadyExists if that hash is already active in the kind,
+// the pointer database only stages byte slices, so the content is not streamed and the memory used grows with the size of the reader
+func (app *application) WriteReader(context uint, kind uint, reader io.Reader) (hash.Hash, error) {
+	// nothing is staged until the whole reader has been consumed, so a reader aborted
+	// with an error (such as a pipe closed using CloseWithError) leaves nothing behind:
+	data, err := io.ReadAll(reader)
 	if err != nil {
 		return nil, err
 	}
 
-	return commits.Fetch(hash)
+	pHash, err := app.hashAdapter.FromBytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = app.write(context, kind, *pHash, data)
+	if errors.Is(err, hashdb.ErrAlreadyExists) {
+		return *pHash, err
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return *pHash, nil
+}
+
+// SyncFrom stages the resources of the remote that are missing locally, the caller must then commit them
+func (app *application) SyncFrom(context uint, remote hashdb.RemoteReader) error {
+	kinds, err := remote.Kinds()
+	if err != nil {
+		return err
+	}
+
+	for _, oneKind := range kinds {
+		err := app.syncKindFrom(context, oneKind, remote)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (app *application) syncKindFrom(context uint, kind uint, remote hashdb.RemoteReader) error {
+	remoteRoot, err := remote.RootHash(kind)
+	if err != nil {
+		return err
+	}
+
+	// a kind that does not exist locally yet is considered empty:
+	localHashes := map[string]bool{}
+	localRoot, err := app.RootHash(context, kind)
+	if err == nil {
+		if localRoot.Compare(remoteRoot) {
+			return nil
+		}
+
+		hashes, err := app.List(context, kind)
+		if err != nil {
+			return err
+		}
+
+		for _, oneHash := range hashes {
+			localHashes[oneHash.String()] = true
+		}
+	}
+
+	remoteHashes, err := remote.List(kind)
+	if err != nil {
+		return err
+	}
+
+	for _, oneHash := range remoteHashes {
+		if _, ok := localHashes[oneHash.String()]; ok {
+			continue
+		}
+
+		data, err := remote.Read(kind, oneHash)
+		if err != nil {
+			return err
+		}
+
+		pHash, err := app.hashAdapter.FromBytes(data)
+		if err != nil {
+			return err
+		}
+
+		if !pHash.Compare(oneHash) {
+			str := fmt.Sprintf("the remote resource (kind: %d, hash: %s) does not match the hash of its data (%s)", kind, oneHash.String(), pHash.String())
+			return errors.New(str)
+		}
+
+		err = app.write(context, kind, oneHash, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NewBatch creates a new batch on a context
+func (app *application) NewBatch(context uint) hashdb.Batch {
+	return createBatch(app, context)
+}
+
+// Verify verifies the content keys of a kind and the commit history, then returns an error on the first problem found,
+// the kinds share the same data region but only the pointers of the given kind are checked for overlaps, so overlaps across kinds are not detected
+func (app *application) Verify(context uint, kind uint) error {
+	keys, err := app.pointerDB.ContentKeys(context, kind)
+	if err != nil {
+		return err
+	}
+
+	list := keys.List()
+	err = app.verifyPointers(list)
+	if err != nil {
+		return err
+	}
+
+	commits, err := app.pointerDB.Commits(context)
+	if err != nil {
+		return err
+	}
+
+	return app.verifyCommits(commits)
+}
+
+func (app *application) verifyCommits(commits references.Commits) error {
+	list := commits.List()
+	for _, oneCommit := range list {
+		// the hash of a commit is computed from its action, creation time and parent:
+		builder := app.commitBuilder.Create().WithAction(oneCommit.Action()).CreatedOn(oneCommit.CreatedOn())
+		if oneCommit.HasParent() {
+			builder.WithParent(*oneCommit.Parent())
+		}
+
+		recomputed, err := builder.Now()
+		if err != nil {
+			return err
+		}
+
+		if !recomputed.Hash().Compare(oneCommit.Hash()) {
+			str := fmt.Sprintf("the commit (hash: %s) does not match the hash recomputed from its content (%s)", oneCommit.Hash().String(), recomputed.Hash().String())
+			return errors.New(str)
+		}
+
+		if !oneCommit.HasParent() {
+			continue
+		}
+
+		parent, err := commits.Fetch(*oneCommit.Parent())
+		if err != nil {
+			return err
+		}
+
+		if oneCommit.CreatedOn().Before(parent.CreatedOn()) {
+			str := fmt.Sprintf("the commit (hash: %s) was created on %s, before its parent (hash: %s) created on %s", oneCommit.Hash().String(), oneCommit.CreatedOn().String(), parent.Hash().String(), parent.CreatedOn().String())
+			return errors.New(str)
+		}
+	}
+
+	return nil
+}
+
+func (app *application) verifyPointers(contentKeys []references.ContentKey) error {
+	sorted := make([]references.ContentKey, len(contentKeys))
+	copy(sorted, contentKeys)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Content().From() < sorted[j].Content().From()
+	})
+
+	for i := 1; i < len(sorted); i++ {
+		previous := sorted[i-1]
+		current := sorted[i]
+		previousEnd := previous.Content().From() + previous.Content().Length()
+		if current.Content().From() < previousEnd {
+			str := fmt.Sprintf("the resource (kind: %d, hash: %s) begins at %d, before the end (%d) of the resource (kind: %d, hash: %s)", current.Kind(), current.Hash().String(), current.Content().From(), previousEnd, previous.Kind(), previous.Hash().String())
+			return errors.New(str)
+		}
+	}
+
+	return nil
+}
+
+// Orphans returns the content keys of a kind whose commit does not exist in the commit history
+func (app *application) Orphans(context uint, kind uint) ([]references.ContentKey, error) {
+	keys, err := app.pointerDB.ContentKeys(context, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	commits, err := app.pointerDB.Commits(context)
+	if err != nil {
+		return nil, err
+	}
+
+	output := []references.ContentKey{}
+	list := keys.List()
+	for _, oneContentKey := range list {
+		_, err := commits.Fetch(oneContentKey.Commit())
+		if err == nil {
+			continue
+		}
+
+		output = append(output, oneContentKey)
+	}
+
+	return output, nil
+}
+
+// ExportKind writes the kind, hash, length and content of every resource of a kind to the writer
+func (app *application) ExportKind(context uint, kind uint, writer io.Writer) error {
+	keys, err := app.pointerDB.ContentKeys(context, kind)
+	if err != nil {
+		return err
+	}
+
+	list := keys.List()
+	for _, oneContentKey := range list {
+		content, err := app.readPointer(context, oneContentKey.Content())
+		if err != nil {
+			return err
+		}
+
+		kindBytes := make([]byte, exportedUintLength)
+		binary.LittleEndian.PutUint64(kindBytes, uint64(kind))
+
+		lengthBytes := make([]byte, exportedUintLength)
+		binary.LittleEndian.PutUint64(lengthBytes, uint64(len(content)))
+
+		data := []byte{}
+		data = append(data, kindBytes...)
+		data = append(data, oneContentKey.Hash().Bytes()...)
+		data = append(data, lengthBytes...)
+		data = append(data, content...)
+		_, err = writer.Write(data)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ImportKind stages the resources exported by ExportKind under their original kind, the caller must then commit them
+func (app *application) ImportKind(context uint, reader io.Reader) error {
+	for {
+		kindBytes := make([]byte, exportedUintLength)
+		_, err := io.ReadFull(reader, kindBytes)
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		hashBytes := make([]byte, hash.Size)
+		_, err = io.ReadFull(reader, hashBytes)
+		if err != nil {
+			return err
+		}
+
+		lengthBytes := make([]byte, exportedUintLength)
+		_, err = io.ReadFull(reader, lengthBytes)
+		if err != nil {
+			return err
+		}
+
+		// the length comes from the reader, so the content is copied as it arrives rather than allocated upfront:
+		kind := uint(binary.LittleEndian.Uint64(kindBytes))
+		length := binary.LittleEndian.Uint64(lengthBytes)
+		if length > math.MaxInt64 {
+			str := fmt.Sprintf("the imported resource (kind: %d) declares an invalid length (%d)", kind, length)
+			return errors.New(str)
+		}
+
+		buffer := bytes.Buffer{}
+		_, err = io.CopyN(&buffer, reader, int64(length))
+		if err != nil {
+			str := fmt.Sprintf("the imported resource (kind: %d) declares a length of %d bytes but its data could not be read: %s", kind, length, err.Error())
+			return errors.New(str)
+		}
+
+		content := buffer.Bytes()
+		pHash, err := app.hashAdapter.FromBytes(content)
+		if err != nil {
+			return err
+		}
+
+		expected := hash.Hash(hashBytes)
+		if !pHash.Compare(expected) {
+			str := fmt.Sprintf("the imported resource (kind: %d, hash: %s) does not match the hash of its data (%s)", kind, expected.String(), pHash.String())
+			return errors.New(str)
+		}
+
+		err = app.write(context, kind, expected, content)
+		if errors.Is(err, hashdb.ErrAlreadyExists) {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Commit returns the commit by hash
+func (app *application) Commit(context uint, hash hash.Hash) (references.Commit, error) {
+	commits, err := app.pointerDB.Commits(context)
+	if err != nil {
+		return nil, err
+	}
+
+	return commits.Fetch(hash)
+}
+
+// CommitTimeRange returns the oldest and newest creation time of the commits of the context
+func (app *application) CommitTimeRange(context uint) (time.Time, time.Time, error) {
+	commits, err := app.pointerDB.Commits(context)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	list := commits.List()
+	if len(list) <= 0 {
+		str := fmt.Sprintf("there is zero (0) Commit in the given context: %d", context)
+		return time.Time{}, time.Time{}, errors.New(str)
+	}
+
+	oldest := list[0].CreatedOn()
+	newest := list[0].CreatedOn()
+	for _, oneCommit := range list {
+		createdOn := oneCommit.CreatedOn()
+		if createdOn.Before(oldest) {
+			oldest = createdOn
+		}
+
+		if createdOn.After(newest) {
+			newest = createdOn
+		}
+	}
+
+	return oldest, newest, nil
+}
+
+// CommitDepth returns the amount of ancestors a commit has back to the first commit
+func (app *application) CommitDepth(context uint, commit hash.Hash) (uint, error) {
+	commits, err := app.pointerDB.Commits(context)
+	if err != nil {
+		return 0, err
+	}
+
+	current, err := commits.Fetch(commit)
+	if err != nil {
+		return 0, err
+	}
+
+	depth := uint(0)
+	for current.HasParent() {
+		current, err = commits.Fetch(*current.Parent())
+		if err != nil {
+			return 0, err
+		}
+
+		depth++
+	}
+
+	return depth, nil
+}
+
+// CommitExists returns true if the commit is in the chain of the context, false otherwise
+func (app *application) CommitExists(context uint, commit hash.Hash) (bool, error) {
+	commits, err := app.pointerDB.Commits(context)
+	if err != nil {
+		return false, err
+	}
+
+	// the commits are indexed by hash, so fetching does not scan the chain:
+	_, err = commits.Fetch(commit)
+	if err != nil {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// CommitsByHashes returns the commits of the given hashes, in the same order, or an error on the first unknown hash
+func (app *application) CommitsByHashes(context uint, hashes []hash.Hash) ([]references.Commit, error) {
+	commits, err := app.pointerDB.Commits(context)
+	if err != nil {
+		return nil, err
+	}
+
+	output := []references.Commit{}
+	for _, oneHash := range hashes {
+		commit, err := commits.Fetch(oneHash)
+		if err != nil {
+			return nil, err
+		}
+
+		output = append(output, commit)
+	}
+
+	return output, nil
+}
+
+// KeyHistory returns every commit that inserted or deleted an hash, oldest first
+func (app *application) KeyHistory(context uint, kind uint, hash hash.Hash) ([]references.Commit, error) {
+	commits, err := app.pointerDB.Commits(context)
+	if err != nil {
+		return nil, err
+	}
+
+	// the list is scanned rather than the parent links, since a commit staging erasures and writes creates two sibling commits,
+	// and the actions only record hashes, so a commit touching the same hash in another kind is also part of the history:
+	output := []references.Commit{}
+	for _, oneCommit := range commits.List() {
+		isPart := false
+		action := oneCommit.Action()
+		if action.HasInsert() {
+			isPart, err = app.treeContains(action.Insert(), hash)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if !isPart && action.HasDelete() {
+			isPart, err = app.treeContains(action.Delete(), hash)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if isPart {
+			output = append(output, oneCommit)
+		}
+	}
+
+	return output, nil
+}
+
+// KindLatestCommit returns the latest commit that inserted an hash currently present in the kind, or that erased any hash,
+// since the erasures do not record kinds, every erasing commit is considered to touch the kind
+func (app *application) KindLatestCommit(context uint, kind uint) (references.Commit, error) {
+	// the pointer database returns an error when the kind has no resource, which happens once all of them are erased:
+	hashes := map[string]struct{}{}
+	contentKeys, err := app.pointerDB.ContentKeys(context, kind)
+	if err == nil && contentKeys != nil {
+		for _, oneContentKey := range contentKeys.List() {
+			hashes[oneContentKey.Hash().String()] = struct{}{}
+		}
+	}
+
+	commits, err := app.pointerDB.Commits(context)
+	if err != nil {
+		return nil, err
+	}
+
+	// the list is scanned rather than the parent links, since a commit staging erasures and writes creates two sibling commits:
+	list := commits.List()
+	for i := len(list) - 1; i >= 0; i-- {
+		action := list[i].Action()
+		if action.HasDelete() {
+			return list[i], nil
+		}
+
+		if !action.HasInsert() {
+			continue
+		}
+
+		isTouched, err := app.treeContainsAny(action.Insert(), hashes)
+		if err != nil {
+			return nil, err
+		}
+
+		if isTouched {
+			return list[i], nil
+		}
+	}
+
+	str := fmt.Sprintf("no commit inserted or deleted a resource of the kind (%d)", kind)
+	return nil, errors.New(str)
+}
+
+// ReadStatus returns whether a resource is present, erased (along with its erasing commit) or never existed,
+// the erasures do not record kinds, so an hash erased from any kind is reported as erased in every kind it is not present in
+func (app *application) ReadStatus(context uint, kind uint, hash hash.Hash) (hashdb.Status, references.Commit, error) {
+	_, err := app.retrieveActiveContentKeyByHash(context, kind, hash)
+	if err == nil {
+		return hashdb.StatusPresent, nil, nil
+	}
+
+	// the pointer database returns an error when the context does not have any commit yet, or when the context is unknown:
+	commits, err := app.pointerDB.Commits(context)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), zeroCommitErrorPrefix) {
+			return hashdb.StatusNeverExisted, nil, nil
+		}
+
+		return hashdb.StatusNeverExisted, nil, err
+	}
+
+	commit, err := app.retrieveDeletingCommit(commits, hash)
+	if err != nil {
+		return hashdb.StatusNeverExisted, nil, err
+	}
+
+	if commit == nil {
+		return hashdb.StatusNeverExisted, nil, nil
+	}
+
+	return hashdb.StatusErased, commit, nil
+}
+
+// DeletedBy returns the latest commit that erased an hash, or nil if the hash is currently present in the kind
+func (app *application) DeletedBy(context uint, kind uint, hash hash.Hash) (references.Commit, error) {
+	_, err := app.retrieveActiveContentKeyByHash(context, kind, hash)
+	if err == nil {
+		return nil, nil
+	}
+
+	commits, err := app.pointerDB.Commits(context)
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := app.retrieveDeletingCommit(commits, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if commit == nil {
+		str := fmt.Sprintf("the resource (kind: %d, hash: %s) is not present and was never erased", kind, hash.String())
+		return nil, errors.New(str)
+	}
+
+	return commit, nil
+}
+
+// CommitIterator returns a function that yields the commits one at a time, from the latest to the first
+func (app *application) CommitIterator(context uint) (func() (references.Commit, bool, error), error) {
+	commits, err := app.pointerDB.Commits(context)
+	if err != nil {
+		return nil, err
+	}
+
+	// a commit that stages both erasures and writes creates two commits with the same parent,
+	// so following the parent links would skip one of them, therefore the list is walked from its end:
+	list := commits.List()
+	index := len(list)
+	return func() (references.Commit, bool, error) {
+		if index <= 0 {
+			return nil, false, nil
+		}
+
+		index--
+		return list[index], true, nil
+	}, nil
+}
+
+func (app *application) validate(kind uint, data []byte) error {
+	if fn, ok := app.validators[kind]; ok {
+		err := fn(data)
+		if err != nil {
+			str := fmt.Sprintf("the data is invalid for the kind (%d): %s", kind, err.Error())
+			return errors.New(str)
+		}
+	}
+
+	return nil
+}
+
+func (app *application) write(context uint, kind uint, hash hash.Hash, data []byte) error {
+	err := app.validate(kind, data)
+	if err != nil {
+		return err
+	}
+
+	// the same hash always has the same data, so writing an active or staged resource again would only stage a duplicate:
+	_, err = app.retrieveActiveContentKeyByHash(context, kind, hash)
+	if err == nil {
+		return hashdb.ErrAlreadyExists
+	}
+
+	app.stagedMutex.Lock()
+	defer app.stagedMutex.Unlock()
+	pStaged := app.retrieveStagedResources(context)
+	keyname := app.makeStagedKeyname(kind, hash)
+	if pStaged.exists(keyname) {
+		return hashdb.ErrAlreadyExists
+	}
+
+	err = app.pointerDB.Write(context, kind, hash, data)
+	if err != nil {
+		return err
+	}
+
+	pStaged.add(keyname)
+	return nil
+}
+
+func (app *application) isStaged(context uint, kind uint, hash hash.Hash) bool {
+	app.stagedMutex.Lock()
+	defer app.stagedMutex.Unlock()
+	return app.retrieveStagedResources(context).exists(app.makeStagedKeyname(kind, hash))
+}
+
+func (app *application) retrieveStagedResources(context uint) *stagedResources {
+	// the staged resources are persisted by the next commit, which changes the latest commit of the context:
+	head := ""
+	commits, err := app.pointerDB.Commits(context)
+	if err == nil {
+		head = commits.Latest().Hash().String()
+	}
+
+	pStaged, ok := app.staged[context]
+	if !ok || pStaged.head != head {
+		pStaged = createStagedResources(head)
+		app.staged[context] = pStaged
+	}
+
+	return pStaged
+}
+
+func (app *application) makeStagedKeyname(kind uint, hash hash.Hash) string {
+	return fmt.Sprintf("%d:%s", kind, hash.String())
+}
+
+func (app *application) readPointer(context uint, pointer references.Pointer) ([]byte, error) {
+	if app.contentCacheSize <= 0 {
+		return app.pointerDB.Read(context, pointer)
+	}
+
+	// the pointers are only valid until the next commit rewrites the data:
+	commits, err := app.pointerDB.Commits(context)
+	if err != nil {
+		return nil, err
+	}
+
+	// reads may be concurrent, so the caches are only swapped under the lock:
+	head := commits.Latest().Hash().String()
+	app.cachesMutex.Lock()
+	pCache, ok := app.contentCaches[context]
+	if !ok || pCache.head != head {
+		pCache = createContentCache(head)
+		app.contentCaches[context] = pCache
+	}
+	app.cachesMutex.Unlock()
+
+	keyname := fmt.Sprintf("%d:%d", pointer.From(), pointer.Length())
+	if data, ok := pCache.fetch(keyname); ok {
+		return data, nil
+	}
+
+	data, err := app.pointerDB.Read(context, pointer)
+	if err != nil {
+		return nil, err
+	}
+
+	pCache.save(keyname, data, app.contentCacheSize)
+	return data, nil
+}
+
+func (app *application) retrieveContentKeysOrEmpty(context uint, kind uint) (references.ContentKeys, error) {
+	keys, err := app.pointerDB.ContentKeys(context, kind)
+	if err == nil {
+		return keys, nil
+	}
+
+	// a kind without any content, or a context without any commit, is considered empty:
+	for _, onePrefix := range emptyContentKeysErrorPrefixes {
+		if strings.HasPrefix(err.Error(), onePrefix) {
+			return nil, nil
+		}
+	}
+
+	return nil, err
+}
+
+func (app *application) retrieveDeletingCommit(commits references.Commits, hash hash.Hash) (references.Commit, error) {
+	// a commit that stages both erasures and writes creates two commits with the same parent,
+	// so following the parent links would skip one of them, therefore the whole list is scanned:
+	list := commits.List()
+	for i := len(list) - 1; i >= 0; i-- {
+		action := list[i].Action()
+		if !action.HasDelete() {
+			continue
+		}
+
+		isDeleted, err := app.treeContains(action.Delete(), hash)
+		if err != nil {
+			return nil, err
+		}
+
+		if isDeleted {
+			return list[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (app *application) treeContains(tree trees.HashTree, hash hash.Hash) (bool, error) {
+	return app.treeContainsAny(tree, map[string]struct{}{
+		hash.String(): {},
+	})
+}
+
+func (app *application) treeContainsAny(tree trees.HashTree, hashes map[string]struct{}) (bool, error) {
+	// the blocks of an action are hashes, therefore they are the heads of its leaves:
+	compact, err := app.hashTreeAdapter.ToCompact(tree)
+	if err != nil {
+		return false, err
+	}
+
+	leaves := compact.Leaves().Leaves()
+	for _, oneLeaf := range leaves {
+		if _, ok := hashes[oneLeaf.Head().String()]; ok {
+			return true, nil
+		}
+	}
+
+	return false, nil
 }
 
 func (app *application) retrieveActiveContentKeyByHash(context uint, kind uint, hash hash.Hash) (references.ContentKey, error) {
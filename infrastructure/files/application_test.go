@@ -2,12 +2,25 @@ package files
 
 import (
 	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
 	"os"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	databases "github.com/steve-care-software/databases/applications"
+	"github.com/steve-care-software/databases/domain/references"
 	infrastructure_database_files "github.com/steve-care-software/databases/infrastructure/files"
+	"github.com/steve-care-software/hashdb/applications"
 	"github.com/steve-care-software/libs/cryptography/hash"
+	"github.com/steve-care-software/libs/cryptography/trees"
 )
 
 func TestCreate_thenOpen_thenWrite_thenRead_Success(t *testing.T) {
@@ -21,7 +34,7 @@ func TestCreate_thenOpen_thenWrite_thenRead_Success(t *testing.T) {
 
 	hashAdapter := hash.NewAdapter()
 	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
-	hashDB := NewApplication(database)
+	hashDB := NewApplication(database, 0)
 
 	name := "my_name"
 	err := database.New(name)
@@ -229,7 +242,7 @@ func TestCreate_New_Insert_Erase_Success(t *testing.T) {
 
 	name := "my_name"
 	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
-	hashDB := NewApplication(database)
+	hashDB := NewApplication(database, 0)
 
 	err := database.New(name)
 	if err != nil {
@@ -289,7 +302,7 @@ func TestCreate_New_InsertResourceWithSameHashButDifferentKind_Success(t *testin
 
 	name := "my_name"
 	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
-	hashDB := NewApplication(database)
+	hashDB := NewApplication(database, 0)
 
 	err := database.New(name)
 	if err != nil {
@@ -369,7 +382,7 @@ func TestCreate_Insert_thenDelete_thenInsert_SameKind_DifferentData_thenRead_Suc
 
 	name := "my_name"
 	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
-	hashDB := NewApplication(database)
+	hashDB := NewApplication(database, 0)
 
 	err := database.New(name)
 	if err != nil {
@@ -477,3 +490,3309 @@ func TestCreate_Insert_thenDelete_thenInsert_SameKind_DifferentData_thenRead_Suc
 		return
 	}
 }
+
+func TestCreate_thenWriteReader_thenRead_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, 0)
+
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+	data := bytes.Repeat([]byte("this is some read data"), 200000)
+	kind := uint(0)
+	retHash, err := hashDB.WriteReader(*pContext, kind, bytes.NewReader(data))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pExpectedHash, err := hash.NewAdapter().FromBytes(data)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !retHash.Compare(*pExpectedHash) {
+		t.Errorf("the returned hash is invalid")
+		return
+	}
+
+	err = database.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	retData, err := hashDB.Read(*pContext, kind, retHash)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if bytes.Compare(retData, data) != 0 {
+		t.Errorf("the returned data is invalid")
+		return
+	}
+}
+
+func TestCreate_thenWrite_thenFindByPrefix_thenResolvePrefix_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, 0)
+
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+
+	// find two resources whose hash share the same first character:
+	hashAdapter := hash.NewAdapter()
+	firstData := []byte("this is data 0")
+	pFirstHash, err := hashAdapter.FromBytes(firstData)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	prefix := pFirstHash.String()[:1]
+	secondData := []byte{}
+	for i := 1; i < 1000; i++ {
+		data := []byte(fmt.Sprintf("this is data %d", i))
+		pHash, err := hashAdapter.FromBytes(data)
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		if strings.HasPrefix(pHash.String(), prefix) {
+			secondData = data
+			break
+		}
+	}
+
+	kind := uint(0)
+	_, err = hashDB.WriteReader(*pContext, kind, bytes.NewReader(firstData))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	_, err = hashDB.WriteReader(*pContext, kind, bytes.NewReader(secondData))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = database.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	retContentKeys, err := hashDB.FindByPrefix(*pContext, kind, prefix)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if len(retContentKeys) != 2 {
+		t.Errorf("%d contentKeys were expected, %d returned", 2, len(retContentKeys))
+		return
+	}
+
+	_, err = hashDB.ResolvePrefix(*pContext, kind, prefix)
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+
+	retContentKey, err := hashDB.ResolvePrefix(*pContext, kind, pFirstHash.String()[:20])
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !retContentKey.Hash().Compare(*pFirstHash) {
+		t.Errorf("the resolved contentKey is invalid")
+		return
+	}
+}
+
+func TestCreate_thenCommitMultipleTimes_thenIterateCommits_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, 0)
+
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+
+	amount := 20
+	kind := uint(0)
+	for i := 0; i < amount; i++ {
+		data := []byte(fmt.Sprintf("this is data %d", i))
+		_, err = hashDB.WriteReader(*pContext, kind, bytes.NewReader(data))
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		err = database.Commit(*pContext)
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+	}
+
+	// a commit that stages both an erasure and a write creates two commits with the same parent:
+	pLastHash, err := hash.NewAdapter().FromBytes([]byte(fmt.Sprintf("this is data %d", amount-1)))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = hashDB.Erase(*pContext, kind, *pLastHash)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	_, err = hashDB.WriteReader(*pContext, kind, bytes.NewReader([]byte("this is the replacing data")))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = database.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	retCommits, err := database.Commits(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	list := retCommits.List()
+	next, err := hashDB.CommitIterator(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	count := 0
+	for {
+		retCommit, ok, err := next()
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		if !ok {
+			break
+		}
+
+		expected := list[len(list)-1-count]
+		if !retCommit.Hash().Compare(expected.Hash()) {
+			t.Errorf("the commit at index %d is invalid", count)
+			return
+		}
+
+		count++
+	}
+
+	if count != amount+2 || count != len(list) {
+		t.Errorf("%d commits were expected, %d returned", amount+2, count)
+		return
+	}
+}
+
+type countingDatabase struct {
+	databases.Application
+	reads   int
+	commits int
+}
+
+// Read counts the reads then reads a pointer on a context
+func (app *countingDatabase) Read(context uint, pointer references.Pointer) ([]byte, error) {
+	app.reads++
+	return app.Application.Read(context, pointer)
+}
+
+// Commit counts the commits then commits a context
+func (app *countingDatabase) Commit(context uint) error {
+	app.commits++
+	return app.Application.Commit(context)
+}
+
+func TestCreate_withContentCache_thenRead_thenCommit_thenRead_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	contentCacheSize := uint(1024)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := &countingDatabase{
+		Application: infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize),
+	}
+
+	hashDB := NewApplication(database, contentCacheSize)
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+	data := []byte("this is some data")
+	kind := uint(0)
+	retHash, err := hashDB.WriteReader(*pContext, kind, bytes.NewReader(data))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = database.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	for i := 0; i < 3; i++ {
+		retData, err := hashDB.Read(*pContext, kind, retHash)
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		if bytes.Compare(retData, data) != 0 {
+			t.Errorf("the returned data is invalid")
+			return
+		}
+	}
+
+	if database.reads != 1 {
+		t.Errorf("%d reads were expected on the database, %d executed", 1, database.reads)
+		return
+	}
+
+	// a new commit rewrites the data, therefore the cache is invalidated:
+	_, err = hashDB.WriteReader(*pContext, kind, bytes.NewReader([]byte("this is some other data")))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = database.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	retData, err := hashDB.Read(*pContext, kind, retHash)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if bytes.Compare(retData, data) != 0 {
+		t.Errorf("the returned data is invalid")
+		return
+	}
+
+	if database.reads != 2 {
+		t.Errorf("%d reads were expected on the database, %d executed", 2, database.reads)
+		return
+	}
+}
+
+func TestCreate_withContentCache_thenRead_thenMutateReturnedData_thenRead_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	contentCacheSize := uint(1024)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, contentCacheSize)
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+	data := []byte("this is some data")
+	kind := uint(0)
+	retHash, err := hashDB.WriteReader(*pContext, kind, bytes.NewReader(data))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = database.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// the first read saves the data in the cache, the next ones fetch it from the cache:
+	for i := 0; i < 3; i++ {
+		retData, err := hashDB.Read(*pContext, kind, retHash)
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		if bytes.Compare(retData, data) != 0 {
+			t.Errorf("the returned data is invalid")
+			return
+		}
+
+		retData[0] = 'X'
+	}
+}
+
+func TestCreate_thenWriteSameContentInDifferentOrder_thenRootHash_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, 0)
+
+	firstName := "my_first_name"
+	err := database.New(firstName)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	secondName := "my_second_name"
+	err = database.New(secondName)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pFirstContext, err := database.Open(firstName)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pFirstContext)
+	pSecondContext, err := database.Open(secondName)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pSecondContext)
+
+	kind := uint(0)
+	dataList := [][]byte{
+		[]byte("this is the first data"),
+		[]byte("this is the second data"),
+		[]byte("this is the third data"),
+	}
+
+	// write in order, in a single commit, on the first database:
+	for _, oneData := range dataList {
+		_, err = hashDB.WriteReader(*pFirstContext, kind, bytes.NewReader(oneData))
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+	}
+
+	err = database.Commit(*pFirstContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// write in reverse order, one commit at a time, on the second database:
+	for i := len(dataList) - 1; i >= 0; i-- {
+		_, err = hashDB.WriteReader(*pSecondContext, kind, bytes.NewReader(dataList[i]))
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		err = database.Commit(*pSecondContext)
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+	}
+
+	retFirstRoot, err := hashDB.RootHash(*pFirstContext, kind)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	retSecondRoot, err := hashDB.RootHash(*pSecondContext, kind)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !retFirstRoot.Compare(retSecondRoot) {
+		t.Errorf("the root hashes were expected to be the same")
+		return
+	}
+}
+
+type localRemoteReader struct {
+	hashDB  applications.Application
+	context uint
+	kinds   []uint
+}
+
+// Kinds returns the kinds
+func (app *localRemoteReader) Kinds() ([]uint, error) {
+	return app.kinds, nil
+}
+
+// RootHash returns the root hash of a kind
+func (app *localRemoteReader) RootHash(kind uint) (hash.Hash, error) {
+	return app.hashDB.RootHash(app.context, kind)
+}
+
+// List returns the hashes of a kind
+func (app *localRemoteReader) List(kind uint) ([]hash.Hash, error) {
+	return app.hashDB.List(app.context, kind)
+}
+
+// Read reads content by hash
+func (app *localRemoteReader) Read(kind uint, hash hash.Hash) ([]byte, error) {
+	return app.hashDB.Read(app.context, kind, hash)
+}
+
+func TestCreate_thenWriteDifferentContent_thenSyncFrom_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, 0)
+
+	localName := "my_local_name"
+	err := database.New(localName)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	remoteName := "my_remote_name"
+	err = database.New(remoteName)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pLocalContext, err := database.Open(localName)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pLocalContext)
+	pRemoteContext, err := database.Open(remoteName)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pRemoteContext)
+
+	kind := uint(0)
+	localList := [][]byte{
+		[]byte("this is the first data"),
+	}
+
+	remoteList := [][]byte{
+		[]byte("this is the first data"),
+		[]byte("this is the second data"),
+		[]byte("this is the third data"),
+	}
+
+	for _, oneData := range localList {
+		_, err = hashDB.WriteReader(*pLocalContext, kind, bytes.NewReader(oneData))
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+	}
+
+	err = database.Commit(*pLocalContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	for _, oneData := range remoteList {
+		_, err = hashDB.WriteReader(*pRemoteContext, kind, bytes.NewReader(oneData))
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+	}
+
+	err = database.Commit(*pRemoteContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	remote := &localRemoteReader{
+		hashDB:  hashDB,
+		context: *pRemoteContext,
+		kinds:   []uint{kind},
+	}
+
+	err = hashDB.SyncFrom(*pLocalContext, remote)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = database.Commit(*pLocalContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	retLocalRoot, err := hashDB.RootHash(*pLocalContext, kind)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	retRemoteRoot, err := remote.RootHash(kind)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !retLocalRoot.Compare(retRemoteRoot) {
+		t.Errorf("the root hashes were expected to be the same after the sync")
+		return
+	}
+
+	for _, oneData := range remoteList {
+		pHash, err := hash.NewAdapter().FromBytes(oneData)
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		retData, err := hashDB.Read(*pLocalContext, kind, *pHash)
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		if bytes.Compare(retData, oneData) != 0 {
+			t.Errorf("the returned data is invalid")
+			return
+		}
+	}
+}
+
+func TestCreate_withContentCache_thenWarm_thenRead_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	contentCacheSize := uint(1024)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := &countingDatabase{
+		Application: infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize),
+	}
+
+	hashDB := NewApplication(database, contentCacheSize)
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+
+	kind := uint(0)
+	dataList := [][]byte{
+		[]byte("this is the first data"),
+		[]byte("this is the second data"),
+		[]byte("this is the third data"),
+	}
+
+	hashes := []hash.Hash{}
+	for _, oneData := range dataList {
+		retHash, err := hashDB.WriteReader(*pContext, kind, bytes.NewReader(oneData))
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		hashes = append(hashes, retHash)
+	}
+
+	err = database.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = hashDB.Warm(*pContext, kind)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if database.reads != len(dataList) {
+		t.Errorf("%d reads were expected on the database, %d executed", len(dataList), database.reads)
+		return
+	}
+
+	retDataList, err := hashDB.ReadAll(*pContext, kind, hashes)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !reflect.DeepEqual(retDataList, dataList) {
+		t.Errorf("the returned data is invalid")
+		return
+	}
+
+	if database.reads != len(dataList) {
+		t.Errorf("the reads were expected to be served by the warmed cache, %d reads executed on the database", database.reads)
+		return
+	}
+}
+
+func TestCreate_thenWrite_thenLength_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := &countingDatabase{
+		Application: infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize),
+	}
+
+	hashDB := NewApplication(database, 0)
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+	data := bytes.Repeat([]byte("0123456789"), 1234)
+	kind := uint(0)
+	retHash, err := hashDB.WriteReader(*pContext, kind, bytes.NewReader(data))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = database.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	retLength, err := hashDB.Length(*pContext, kind, retHash)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if retLength != uint(len(data)) {
+		t.Errorf("the length was expected to be %d, %d returned", len(data), retLength)
+		return
+	}
+
+	if database.reads != 0 {
+		t.Errorf("the length was expected to be returned without reading the content, %d reads executed", database.reads)
+		return
+	}
+}
+
+func TestCreate_thenWrite_thenReadWithKey_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, 0)
+
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+	data := []byte("this is some data")
+	kind := uint(3)
+	retHash, err := hashDB.WriteReader(*pContext, kind, bytes.NewReader(data))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = database.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	retCommits, err := database.Commits(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	retData, retContentKey, err := hashDB.ReadWithKey(*pContext, kind, retHash)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if bytes.Compare(retData, data) != 0 {
+		t.Errorf("the returned data is invalid")
+		return
+	}
+
+	if !retContentKey.Hash().Compare(retHash) {
+		t.Errorf("the returned contentKey hash is invalid")
+		return
+	}
+
+	if retContentKey.Kind() != kind {
+		t.Errorf("the returned contentKey kind was expected to be %d, %d returned", kind, retContentKey.Kind())
+		return
+	}
+
+	if !retContentKey.Commit().Compare(retCommits.Latest().Hash()) {
+		t.Errorf("the returned contentKey commit is invalid")
+		return
+	}
+}
+
+type staticDatabase struct {
+	databases.Application
+	contentKeys references.ContentKeys
+	commits     references.Commits
+}
+
+// ContentKeys returns the static contentKeys
+func (app *staticDatabase) ContentKeys(context uint, kind uint) (references.ContentKeys, error) {
+	return app.contentKeys, nil
+}
+
+// Commits returns the static commits
+func (app *staticDatabase) Commits(context uint) (references.Commits, error) {
+	return app.commits, nil
+}
+
+func createContentKeyForTests(t *testing.T, data []byte, kind uint, from uint, length uint, commit hash.Hash) references.ContentKey {
+	pHash, err := hash.NewAdapter().FromBytes(data)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return nil
+	}
+
+	pointer, err := references.NewPointerBuilder().Create().From(from).WithLength(length).Now()
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return nil
+	}
+
+	contentKey, err := references.NewContentKeyBuilder().Create().WithHash(*pHash).WithKind(kind).WithContent(pointer).WithCommit(commit).Now()
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return nil
+	}
+
+	return contentKey
+}
+
+func TestVerify_withOverlappingPointers_returnsError(t *testing.T) {
+	commit := createCommitForTests(t, [][]byte{
+		[]byte("first"),
+		[]byte("second"),
+	}, time.Now().UTC(), nil)
+
+	commits, err := references.NewCommitsBuilder().Create().WithList([]references.Commit{
+		commit,
+	}).Now()
+
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	commitHash := commit.Hash()
+	pCommitHash := &commitHash
+
+	kind := uint(0)
+	validContentKeys, err := references.NewContentKeysBuilder().Create().WithList([]references.ContentKey{
+		createContentKeyForTests(t, []byte("first"), kind, 0, 10, *pCommitHash),
+		createContentKeyForTests(t, []byte("second"), kind, 10, 10, *pCommitHash),
+	}).Now()
+
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	hashDB := NewApplication(&staticDatabase{
+		contentKeys: validContentKeys,
+		commits:     commits,
+	}, 0)
+
+	err = hashDB.Verify(0, kind)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	overlappingContentKeys, err := references.NewContentKeysBuilder().Create().WithList([]references.ContentKey{
+		createContentKeyForTests(t, []byte("second"), kind, 8, 10, *pCommitHash),
+		createContentKeyForTests(t, []byte("first"), kind, 0, 10, *pCommitHash),
+	}).Now()
+
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	hashDB = NewApplication(&staticDatabase{
+		contentKeys: overlappingContentKeys,
+		commits:     commits,
+	}, 0)
+
+	err = hashDB.Verify(0, kind)
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestCreate_thenWrite_thenReadKind_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, 0)
+
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+
+	kind := uint(0)
+	configs := [][]byte{
+		[]byte(`{"name": "first"}`),
+		[]byte(`{"name": "second"}`),
+		[]byte(`{"name": "third"}`),
+	}
+
+	expected := map[string][]byte{}
+	for _, oneConfig := range configs {
+		retHash, err := hashDB.WriteReader(*pContext, kind, bytes.NewReader(oneConfig))
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		expected[retHash.String()] = oneConfig
+	}
+
+	// other kinds are not returned:
+	_, err = hashDB.WriteReader(*pContext, kind+1, bytes.NewReader([]byte("this is another kind")))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = database.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	retKind, err := hashDB.ReadKind(*pContext, kind)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !reflect.DeepEqual(retKind, expected) {
+		t.Errorf("the returned kind is invalid")
+		return
+	}
+}
+
+func createCommitForTests(t *testing.T, blocks [][]byte, createdOn time.Time, pParent *hash.Hash) references.Commit {
+	tree, err := trees.NewBuilder().Create().WithBlocks(blocks).Now()
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return nil
+	}
+
+	action, err := references.NewActionBuilder().Create().WithInsert(tree).Now()
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return nil
+	}
+
+	builder := references.NewCommitBuilder().Create().WithAction(action).CreatedOn(createdOn)
+	if pParent != nil {
+		builder.WithParent(*pParent)
+	}
+
+	commit, err := builder.Now()
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return nil
+	}
+
+	return commit
+}
+
+func TestOrphans_withUnknownCommit_Success(t *testing.T) {
+	commit := createCommitForTests(t, [][]byte{
+		[]byte("first"),
+	}, time.Now().UTC(), nil)
+
+	commits, err := references.NewCommitsBuilder().Create().WithList([]references.Commit{
+		commit,
+	}).Now()
+
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pUnknownCommitHash, err := hash.NewAdapter().FromBytes([]byte("this is an unknown commit"))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	kind := uint(0)
+	orphan := createContentKeyForTests(t, []byte("second"), kind, 10, 10, *pUnknownCommitHash)
+	contentKeys, err := references.NewContentKeysBuilder().Create().WithList([]references.ContentKey{
+		createContentKeyForTests(t, []byte("first"), kind, 0, 10, commit.Hash()),
+		orphan,
+	}).Now()
+
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	hashDB := NewApplication(&staticDatabase{
+		contentKeys: contentKeys,
+		commits:     commits,
+	}, 0)
+
+	retOrphans, err := hashDB.Orphans(0, kind)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if len(retOrphans) != 1 {
+		t.Errorf("%d orphans were expected, %d returned", 1, len(retOrphans))
+		return
+	}
+
+	if !retOrphans[0].Hash().Compare(orphan.Hash()) {
+		t.Errorf("the returned orphan is invalid")
+		return
+	}
+}
+
+func TestCreate_thenWrite_thenStatAll_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := &countingDatabase{
+		Application: infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize),
+	}
+
+	hashDB := NewApplication(database, 0)
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+
+	kind := uint(0)
+	dataList := [][]byte{
+		[]byte("this is the first data"),
+		[]byte("this is the second, longer, data"),
+		[]byte("this is the third data, the longest of all the data"),
+	}
+
+	hashes := []hash.Hash{}
+	for _, oneData := range dataList {
+		retHash, err := hashDB.WriteReader(*pContext, kind, bytes.NewReader(oneData))
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		hashes = append([]hash.Hash{retHash}, hashes...)
+
+		err = database.Commit(*pContext)
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+	}
+
+	retCommits, err := database.Commits(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	retStats, err := hashDB.StatAll(*pContext, kind, hashes)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if len(retStats) != len(hashes) {
+		t.Errorf("%d stats were expected, %d returned", len(hashes), len(retStats))
+		return
+	}
+
+	commitsList := retCommits.List()
+	for index, oneStat := range retStats {
+		dataIndex := len(dataList) - 1 - index
+		if !oneStat.Hash.Compare(hashes[index]) {
+			t.Errorf("the stat (index: %d) hash is invalid", index)
+			return
+		}
+
+		if oneStat.Kind != kind {
+			t.Errorf("the stat (index: %d) kind was expected to be %d, %d returned", index, kind, oneStat.Kind)
+			return
+		}
+
+		if oneStat.Length != uint(len(dataList[dataIndex])) {
+			t.Errorf("the stat (index: %d) length was expected to be %d, %d returned", index, len(dataList[dataIndex]), oneStat.Length)
+			return
+		}
+
+		if !oneStat.Commit.Compare(commitsList[dataIndex].Hash()) {
+			t.Errorf("the stat (index: %d) commit is invalid", index)
+			return
+		}
+
+		if !oneStat.CreatedOn.Equal(commitsList[dataIndex].CreatedOn()) {
+			t.Errorf("the stat (index: %d) creation time is invalid", index)
+			return
+		}
+	}
+
+	pInvalidHash, err := hash.NewAdapter().FromBytes([]byte("this is an invalid data"))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	_, err = hashDB.StatAll(*pContext, kind, append(hashes, *pInvalidHash))
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+
+	if database.reads != 0 {
+		t.Errorf("the stats were expected to be returned without reading the content, %d reads executed", database.reads)
+		return
+	}
+}
+
+func TestCreate_thenWriteInMultipleCommits_thenListRecent_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, 0)
+
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+
+	kind := uint(0)
+	hashes := []hash.Hash{}
+	for i := 0; i < 5; i++ {
+		data := []byte(fmt.Sprintf("this is data %d", i))
+		retHash, err := hashDB.WriteReader(*pContext, kind, bytes.NewReader(data))
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		hashes = append(hashes, retHash)
+		err = database.Commit(*pContext)
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	limit := uint(3)
+	retContentKeys, err := hashDB.ListRecent(*pContext, kind, limit)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if uint(len(retContentKeys)) != limit {
+		t.Errorf("%d contentKeys were expected, %d returned", limit, len(retContentKeys))
+		return
+	}
+
+	for index, oneContentKey := range retContentKeys {
+		expected := hashes[len(hashes)-1-index]
+		if !oneContentKey.Hash().Compare(expected) {
+			t.Errorf("the contentKey at index %d was expected to be the #%d most recent", index, index+1)
+			return
+		}
+	}
+}
+
+func TestCreate_thenWrite_thenExportKind_thenImportKind_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, 0)
+
+	sourceName := "my_source_name"
+	err := database.New(sourceName)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	targetName := "my_target_name"
+	err = database.New(targetName)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pSourceContext, err := database.Open(sourceName)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pSourceContext)
+	pTargetContext, err := database.Open(targetName)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pTargetContext)
+
+	kind := uint(2)
+	dataList := [][]byte{
+		[]byte("this is the first data"),
+		[]byte("this is the second data"),
+	}
+
+	for _, oneData := range dataList {
+		_, err = hashDB.WriteReader(*pSourceContext, kind, bytes.NewReader(oneData))
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+	}
+
+	otherKind := uint(3)
+	_, err = hashDB.WriteReader(*pSourceContext, otherKind, bytes.NewReader([]byte("this is another kind")))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = database.Commit(*pSourceContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	exported := bytes.NewBuffer(nil)
+	err = hashDB.ExportKind(*pSourceContext, kind, exported)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = hashDB.ImportKind(*pTargetContext, exported)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = database.Commit(*pTargetContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	retSource, err := hashDB.ReadKind(*pSourceContext, kind)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	retTarget, err := hashDB.ReadKind(*pTargetContext, kind)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !reflect.DeepEqual(retSource, retTarget) {
+		t.Errorf("the imported kind was expected to match the exported kind")
+		return
+	}
+
+	_, err = hashDB.List(*pTargetContext, otherKind)
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestVerify_withCommitCreatedBeforeItsParent_returnsError(t *testing.T) {
+	createdOn := time.Now().UTC()
+	first := createCommitForTests(t, [][]byte{
+		[]byte("first"),
+	}, createdOn, nil)
+
+	firstHash := first.Hash()
+	second := createCommitForTests(t, [][]byte{
+		[]byte("second"),
+	}, createdOn.Add(-1*time.Hour), &firstHash)
+
+	commits, err := references.NewCommitsBuilder().Create().WithList([]references.Commit{
+		first,
+		second,
+	}).Now()
+
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	kind := uint(0)
+	contentKeys, err := references.NewContentKeysBuilder().Create().WithList([]references.ContentKey{
+		createContentKeyForTests(t, []byte("first"), kind, 0, 10, first.Hash()),
+		createContentKeyForTests(t, []byte("second"), kind, 10, 10, second.Hash()),
+	}).Now()
+
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	hashDB := NewApplication(&staticDatabase{
+		contentKeys: contentKeys,
+		commits:     commits,
+	}, 0)
+
+	err = hashDB.Verify(0, kind)
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestCreate_thenWrite_thenMissingFrom_thenExtraIn_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, 0)
+
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+
+	hashAdapter := hash.NewAdapter()
+	hashes := []hash.Hash{}
+	for i := 0; i < 4; i++ {
+		pHash, err := hashAdapter.FromBytes([]byte(fmt.Sprintf("this is data %d", i)))
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		hashes = append(hashes, *pHash)
+	}
+
+	// the database does not have any commit yet, so every candidate is missing and there is no extra hash:
+	kind := uint(0)
+	retMissing, err := hashDB.MissingFrom(*pContext, kind, hashes)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !reflect.DeepEqual(retMissing, hashes) {
+		t.Errorf("the missing hashes are invalid")
+		return
+	}
+
+	retExtra, err := hashDB.ExtraIn(*pContext, kind, hashes)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if len(retExtra) != 0 {
+		t.Errorf("%d extra hashes were expected, %d returned", 0, len(retExtra))
+		return
+	}
+
+	// the database contains the first three hashes:
+	for i := 0; i < 3; i++ {
+		_, err = hashDB.WriteReader(*pContext, kind, bytes.NewReader([]byte(fmt.Sprintf("this is data %d", i))))
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+	}
+
+	err = database.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// overlapping candidates:
+	candidates := []hash.Hash{
+		hashes[1],
+		hashes[2],
+		hashes[3],
+	}
+
+	retMissing, err = hashDB.MissingFrom(*pContext, kind, candidates)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !reflect.DeepEqual(retMissing, []hash.Hash{hashes[3]}) {
+		t.Errorf("the missing hashes are invalid")
+		return
+	}
+
+	retExtra, err = hashDB.ExtraIn(*pContext, kind, candidates)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !reflect.DeepEqual(retExtra, []hash.Hash{hashes[0]}) {
+		t.Errorf("the extra hashes are invalid")
+		return
+	}
+
+	// disjoint candidates:
+	disjoint := []hash.Hash{
+		hashes[3],
+	}
+
+	retMissing, err = hashDB.MissingFrom(*pContext, kind, disjoint)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !reflect.DeepEqual(retMissing, disjoint) {
+		t.Errorf("the missing hashes are invalid")
+		return
+	}
+
+	retExtra, err = hashDB.ExtraIn(*pContext, kind, disjoint)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if len(retExtra) != 3 {
+		t.Errorf("%d extra hashes were expected, %d returned", 3, len(retExtra))
+		return
+	}
+
+	// a kind without any content is considered empty:
+	emptyKind := uint(1)
+	retMissing, err = hashDB.MissingFrom(*pContext, emptyKind, candidates)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !reflect.DeepEqual(retMissing, candidates) {
+		t.Errorf("the missing hashes are invalid")
+		return
+	}
+
+	retExtra, err = hashDB.ExtraIn(*pContext, emptyKind, candidates)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if len(retExtra) != 0 {
+		t.Errorf("%d extra hashes were expected, %d returned", 0, len(retExtra))
+		return
+	}
+}
+
+func TestCreate_thenWrite_thenErase_thenDeletedBy_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, 0)
+
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+
+	kind := uint(0)
+	firstHash, err := hashDB.WriteReader(*pContext, kind, bytes.NewReader([]byte("this is the first data")))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = database.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	secondHash, err := hashDB.WriteReader(*pContext, kind, bytes.NewReader([]byte("this is the second data")))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = database.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = hashDB.Erase(*pContext, kind, secondHash)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = database.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	retCommits, err := database.Commits(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	retCommit, err := hashDB.DeletedBy(*pContext, kind, secondHash)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !retCommit.Hash().Compare(retCommits.Latest().Hash()) {
+		t.Errorf("the returned commit was expected to be the erasing commit")
+		return
+	}
+
+	retCommit, err = hashDB.DeletedBy(*pContext, kind, firstHash)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if retCommit != nil {
+		t.Errorf("the commit was expected to be nil because the resource is present")
+		return
+	}
+
+	pNeverHash, err := hash.NewAdapter().FromBytes([]byte("this was never written"))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	_, err = hashDB.DeletedBy(*pContext, kind, *pNeverHash)
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestCreate_thenWrite_thenReadByKey_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, 0)
+
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+
+	kind := uint(0)
+	for i := 0; i < 3; i++ {
+		_, err = hashDB.WriteReader(*pContext, kind, bytes.NewReader([]byte(fmt.Sprintf("this is data %d", i))))
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+	}
+
+	err = database.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	retContentKeys, err := database.ContentKeys(*pContext, kind)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	list := retContentKeys.List()
+	for _, oneContentKey := range list {
+		retData, err := hashDB.ReadByKey(*pContext, oneContentKey)
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		retExpected, err := hashDB.Read(*pContext, kind, oneContentKey.Hash())
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		if bytes.Compare(retData, retExpected) != 0 {
+			t.Errorf("the data read by contentKey was expected to match the data read by hash")
+			return
+		}
+	}
+}
+
+func TestCreate_withValidator_thenWriteReader_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, 0)
+
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+
+	jsonKind := uint(1)
+	hashDB.RegisterValidator(jsonKind, func(data []byte) error {
+		if !json.Valid(data) {
+			return errors.New("the data is not valid JSON")
+		}
+
+		return nil
+	})
+
+	_, err = hashDB.WriteReader(*pContext, jsonKind, bytes.NewReader([]byte(`{"name": `)))
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+
+	_, err = hashDB.WriteReader(*pContext, jsonKind, bytes.NewReader([]byte(`{"name": "valid"}`)))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// kinds without validator are not validated:
+	_, err = hashDB.WriteReader(*pContext, jsonKind+1, bytes.NewReader([]byte(`{"name": `)))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = database.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	retHashes, err := hashDB.List(*pContext, jsonKind)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if len(retHashes) != 1 {
+		t.Errorf("%d resources were expected, %d returned", 1, len(retHashes))
+		return
+	}
+}
+
+func TestCreate_thenWrite_thenCommitExists_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, 0)
+
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+
+	_, err = hashDB.WriteReader(*pContext, uint(1), bytes.NewReader([]byte("this is some data")))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = database.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	commits, err := database.Commits(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	exists, err := hashDB.CommitExists(*pContext, commits.Latest().Hash())
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !exists {
+		t.Errorf("the commit was expected to exist")
+		return
+	}
+
+	pRandom, err := hash.NewAdapter().FromBytes([]byte("this is a random commit"))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	exists, err = hashDB.CommitExists(*pContext, *pRandom)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if exists {
+		t.Errorf("the commit was NOT expected to exist")
+		return
+	}
+}
+
+func TestCreate_thenCommitMultipleTimes_thenCommitsByHashes_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, 0)
+
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+
+	expected := []hash.Hash{}
+	for i := 0; i < 3; i++ {
+		_, err = hashDB.WriteReader(*pContext, uint(1), bytes.NewReader([]byte(fmt.Sprintf("this is the data %d", i))))
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		err = database.Commit(*pContext)
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		commits, err := database.Commits(*pContext)
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		// prepend, to make sure the order of the request is preserved:
+		expected = append([]hash.Hash{commits.Latest().Hash()}, expected...)
+	}
+
+	retCommits, err := hashDB.CommitsByHashes(*pContext, expected)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if len(retCommits) != len(expected) {
+		t.Errorf("%d commits were expected, %d returned", len(expected), len(retCommits))
+		return
+	}
+
+	for idx, oneCommit := range retCommits {
+		if !oneCommit.Hash().Compare(expected[idx]) {
+			t.Errorf("the commit at index %d was expected to be %s, %s returned", idx, expected[idx].String(), oneCommit.Hash().String())
+			return
+		}
+	}
+
+	pRandom, err := hash.NewAdapter().FromBytes([]byte("this is a random commit"))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	_, err = hashDB.CommitsByHashes(*pContext, append(expected, *pRandom))
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestCreate_thenWriteReader_thenAbortMidway_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, 0)
+
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	kind := uint(1)
+	reader, writer := io.Pipe()
+	go func() {
+		writer.Write([]byte("this is the first half of the upload"))
+		writer.CloseWithError(errors.New("the upload was interrupted"))
+	}()
+
+	_, err = hashDB.WriteReader(*pContext, kind, reader)
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+
+	retEntries, err := os.ReadDir(dirPath)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if len(retEntries) != len(entries) {
+		t.Errorf("%d files were expected, %d returned", len(entries), len(retEntries))
+		return
+	}
+
+	_, err = hashDB.WriteReader(*pContext, kind, bytes.NewReader([]byte("this is a complete upload")))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = database.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	retHashes, err := hashDB.List(*pContext, kind)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if len(retHashes) != 1 {
+		t.Errorf("%d resources were expected, %d returned", 1, len(retHashes))
+		return
+	}
+}
+
+func TestNewByHash_thenNewByHashAgain_returnsError(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, 0)
+
+	pSeed, err := hash.NewAdapter().FromBytes([]byte("this is the seed"))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	name, err := hashDB.NewByHash(*pSeed)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if name != pSeed.String() {
+		t.Errorf("the name was expected to be %s, %s returned", pSeed.String(), name)
+		return
+	}
+
+	exists, err := database.Exists(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !exists {
+		t.Errorf("the database was expected to exist")
+		return
+	}
+
+	_, err = hashDB.NewByHash(*pSeed)
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestCommitTimeRange_Success(t *testing.T) {
+	oldest := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	first := createCommitForTests(t, [][]byte{
+		[]byte("first"),
+	}, oldest, nil)
+
+	firstHash := first.Hash()
+	second := createCommitForTests(t, [][]byte{
+		[]byte("second"),
+	}, oldest.Add(24*time.Hour), &firstHash)
+
+	secondHash := second.Hash()
+	newest := oldest.Add(48 * time.Hour)
+	third := createCommitForTests(t, [][]byte{
+		[]byte("third"),
+	}, newest, &secondHash)
+
+	commits, err := references.NewCommitsBuilder().Create().WithList([]references.Commit{
+		first,
+		second,
+		third,
+	}).Now()
+
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	hashDB := NewApplication(&staticDatabase{
+		commits: commits,
+	}, 0)
+
+	retOldest, retNewest, err := hashDB.CommitTimeRange(0)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !retOldest.Equal(oldest) {
+		t.Errorf("the oldest time was expected to be %s, %s returned", oldest.String(), retOldest.String())
+		return
+	}
+
+	if !retNewest.Equal(newest) {
+		t.Errorf("the newest time was expected to be %s, %s returned", newest.String(), retNewest.String())
+		return
+	}
+}
+
+func TestCreate_withoutCommit_thenCommitTimeRange_returnsError(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, 0)
+
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+
+	_, _, err = hashDB.CommitTimeRange(*pContext)
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestCreate_thenWriteReader_thenCommit_thenWriteReaderAgain_returnsErrAlreadyExists(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, 0)
+
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+
+	kind := uint(1)
+	data := []byte("this is some data")
+	expected, err := hashDB.WriteReader(*pContext, kind, bytes.NewReader(data))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = database.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	retHash, err := hashDB.WriteReader(*pContext, kind, bytes.NewReader(data))
+	if !errors.Is(err, applications.ErrAlreadyExists) {
+		t.Errorf("the error was expected to be ErrAlreadyExists, %v returned", err)
+		return
+	}
+
+	if !retHash.Compare(expected) {
+		t.Errorf("the hash was expected to be %s, %s returned", expected.String(), retHash.String())
+		return
+	}
+
+	// the same data under another kind is a different resource:
+	_, err = hashDB.WriteReader(*pContext, kind+1, bytes.NewReader(data))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+}
+
+func TestCreate_thenWrite_thenErase_thenWrite_thenKeyHistory_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, 0)
+
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+
+	kind := uint(0)
+	_, err = hashDB.WriteReader(*pContext, kind, bytes.NewReader([]byte("this is some other data")))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = database.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	data := []byte("this is the data")
+	steps := []func() (hash.Hash, error){
+		func() (hash.Hash, error) {
+			return hashDB.WriteReader(*pContext, kind, bytes.NewReader(data))
+		},
+		func() (hash.Hash, error) {
+			pHash, err := hash.NewAdapter().FromBytes(data)
+			if err != nil {
+				return nil, err
+			}
+
+			return *pHash, hashDB.Erase(*pContext, kind, *pHash)
+		},
+		func() (hash.Hash, error) {
+			return hashDB.WriteReader(*pContext, kind, bytes.NewReader(data))
+		},
+	}
+
+	var dataHash hash.Hash
+	expected := []hash.Hash{}
+	for _, oneStep := range steps {
+		dataHash, err = oneStep()
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		err = database.Commit(*pContext)
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		commits, err := database.Commits(*pContext)
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		expected = append(expected, commits.Latest().Hash())
+	}
+
+	retCommits, err := hashDB.KeyHistory(*pContext, kind, dataHash)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if len(retCommits) != len(expected) {
+		t.Errorf("%d commits were expected, %d returned", len(expected), len(retCommits))
+		return
+	}
+
+	for idx, oneCommit := range retCommits {
+		if !oneCommit.Hash().Compare(expected[idx]) {
+			t.Errorf("the commit at index %d was expected to be %s, %s returned", idx, expected[idx].String(), oneCommit.Hash().String())
+			return
+		}
+	}
+}
+
+func TestCreate_thenCommitMultipleTimes_thenCommitDepth_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, 0)
+
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+
+	for i := 0; i < 4; i++ {
+		_, err = hashDB.WriteReader(*pContext, uint(1), bytes.NewReader([]byte(fmt.Sprintf("this is the data %d", i))))
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		err = database.Commit(*pContext)
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		commits, err := database.Commits(*pContext)
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		depth, err := hashDB.CommitDepth(*pContext, commits.Latest().Hash())
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		if depth != uint(i) {
+			t.Errorf("the depth was expected to be %d, %d returned", i, depth)
+			return
+		}
+	}
+
+	pRandom, err := hash.NewAdapter().FromBytes([]byte("this is a random commit"))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	_, err = hashDB.CommitDepth(*pContext, *pRandom)
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestCreate_thenWrite_thenErase_thenReadStatus_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, 0)
+
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+
+	kind := uint(0)
+	_, err = hashDB.WriteReader(*pContext, kind, bytes.NewReader([]byte("this is some other data")))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = database.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	data := []byte("this is the data")
+	pHash, err := hash.NewAdapter().FromBytes(data)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	status, commit, err := hashDB.ReadStatus(*pContext, kind, *pHash)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if status != applications.StatusNeverExisted || commit != nil {
+		t.Errorf("the resource was expected to have never existed")
+		return
+	}
+
+	_, err = hashDB.WriteReader(*pContext, kind, bytes.NewReader(data))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = database.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	status, commit, err = hashDB.ReadStatus(*pContext, kind, *pHash)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if status != applications.StatusPresent || commit != nil {
+		t.Errorf("the resource was expected to be present")
+		return
+	}
+
+	err = hashDB.Erase(*pContext, kind, *pHash)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = database.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	commits, err := database.Commits(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	status, commit, err = hashDB.ReadStatus(*pContext, kind, *pHash)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if status != applications.StatusErased {
+		t.Errorf("the resource was expected to be erased")
+		return
+	}
+
+	if !commit.Hash().Compare(commits.Latest().Hash()) {
+		t.Errorf("the returned commit was expected to be the erasing commit")
+		return
+	}
+}
+
+func TestCreate_thenReadStatus_withUnknownContext_returnsError(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, 0)
+
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+
+	pHash, err := hash.NewAdapter().FromBytes([]byte("this is the data"))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	_, _, err = hashDB.ReadStatus(uint(999), uint(0), *pHash)
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
+
+type interleavedDatabase struct {
+	databases.Application
+	reads       int
+	onFirstRead func()
+}
+
+// Read executes the interleaved function on the first read, then reads a pointer on a context
+func (app *interleavedDatabase) Read(context uint, pointer references.Pointer) ([]byte, error) {
+	app.reads++
+	if app.reads == 1 {
+		app.onFirstRead()
+	}
+
+	return app.Application.Read(context, pointer)
+}
+
+type alwaysCommittingDatabase struct {
+	staticDatabase
+	history []references.Commits
+	calls   int
+}
+
+// Commits returns the next commits of the history, as if a commit landed between each call
+func (app *alwaysCommittingDatabase) Commits(context uint) (references.Commits, error) {
+	commits := app.history[app.calls%len(app.history)]
+	app.calls++
+	return commits, nil
+}
+
+// Read returns static data
+func (app *alwaysCommittingDatabase) Read(context uint, pointer references.Pointer) ([]byte, error) {
+	return []byte("this is some data"), nil
+}
+
+func TestReadConsistent_withCommitDuringEachAttempt_returnsError(t *testing.T) {
+	createdOn := time.Now().UTC()
+	first := createCommitForTests(t, [][]byte{
+		[]byte("first"),
+	}, createdOn, nil)
+
+	firstHash := first.Hash()
+	second := createCommitForTests(t, [][]byte{
+		[]byte("second"),
+	}, createdOn.Add(time.Second), &firstHash)
+
+	history := []references.Commits{}
+	for _, oneList := range [][]references.Commit{{first}, {first, second}} {
+		commits, err := references.NewCommitsBuilder().Create().WithList(oneList).Now()
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		history = append(history, commits)
+	}
+
+	kind := uint(0)
+	contentKey := createContentKeyForTests(t, []byte("first"), kind, 0, 10, first.Hash())
+	contentKeys, err := references.NewContentKeysBuilder().Create().WithList([]references.ContentKey{
+		contentKey,
+	}).Now()
+
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	database := &alwaysCommittingDatabase{
+		staticDatabase: staticDatabase{
+			contentKeys: contentKeys,
+		},
+		history: history,
+	}
+
+	hashDB := NewApplication(database, 0)
+	_, err = hashDB.ReadConsistent(0, kind, []hash.Hash{
+		contentKey.Hash(),
+	})
+
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+
+	if database.calls != readConsistentMaxAttempts*2 {
+		t.Errorf("%d calls to Commits were expected, %d returned", readConsistentMaxAttempts*2, database.calls)
+		return
+	}
+}
+
+func TestCreate_thenWrite_thenReadConsistent_withInterleavedCommit_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	interleaved := &interleavedDatabase{
+		Application: database,
+	}
+
+	hashDB := NewApplication(interleaved, 0)
+
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+
+	kind := uint(0)
+	data := [][]byte{
+		[]byte("this is the first data"),
+		[]byte("this is the second data"),
+		[]byte("this is the third data"),
+	}
+
+	hashes := []hash.Hash{}
+	for _, oneData := range data {
+		oneHash, err := hashDB.WriteReader(*pContext, kind, bytes.NewReader(oneData))
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		hashes = append(hashes, oneHash)
+	}
+
+	err = database.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	interleaved.onFirstRead = func() {
+		err := hashDB.Erase(*pContext, kind, hashes[2])
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		err = database.Commit(*pContext)
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+	}
+
+	retData, err := hashDB.ReadConsistent(*pContext, kind, hashes[:2])
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !reflect.DeepEqual(retData, data[:2]) {
+		t.Errorf("the returned data is invalid")
+		return
+	}
+
+	// the commit landed during the first pass, so the hashes were read a second time:
+	if interleaved.reads != 4 {
+		t.Errorf("%d reads were expected, %d returned", 4, interleaved.reads)
+		return
+	}
+}
+
+func TestCreate_thenWriteInterleavedKinds_thenKindLatestCommit_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, 0)
+
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+
+	firstKind := uint(1)
+	secondKind := uint(2)
+	kinds := []uint{
+		firstKind,
+		secondKind,
+		firstKind,
+		secondKind,
+		secondKind,
+	}
+
+	expected := map[uint]hash.Hash{}
+	for idx, oneKind := range kinds {
+		_, err = hashDB.WriteReader(*pContext, oneKind, bytes.NewReader([]byte(fmt.Sprintf("this is the data %d", idx))))
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		err = database.Commit(*pContext)
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		commits, err := database.Commits(*pContext)
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		expected[oneKind] = commits.Latest().Hash()
+	}
+
+	for oneKind, oneExpected := range expected {
+		retCommit, err := hashDB.KindLatestCommit(*pContext, oneKind)
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		if !retCommit.Hash().Compare(oneExpected) {
+			t.Errorf("the latest commit of the kind (%d) was expected to be %s, %s returned", oneKind, oneExpected.String(), retCommit.Hash().String())
+			return
+		}
+	}
+
+	_, err = hashDB.KindLatestCommit(*pContext, uint(3))
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
+
+type tamperedCommit struct {
+	references.Commit
+	createdOn time.Time
+}
+
+// CreatedOn returns the altered creation time
+func (obj *tamperedCommit) CreatedOn() time.Time {
+	return obj.createdOn
+}
+
+func TestVerify_withCommitWhoseTimestampWasAltered_returnsError(t *testing.T) {
+	createdOn := time.Now().UTC()
+	first := createCommitForTests(t, [][]byte{
+		[]byte("first"),
+	}, createdOn, nil)
+
+	commits, err := references.NewCommitsBuilder().Create().WithList([]references.Commit{
+		&tamperedCommit{
+			Commit:    first,
+			createdOn: createdOn.Add(-1 * time.Hour),
+		},
+	}).Now()
+
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	kind := uint(0)
+	contentKeys, err := references.NewContentKeysBuilder().Create().WithList([]references.ContentKey{
+		createContentKeyForTests(t, []byte("first"), kind, 0, 10, first.Hash()),
+	}).Now()
+
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	hashDB := NewApplication(&staticDatabase{
+		contentKeys: contentKeys,
+		commits:     commits,
+	}, 0)
+
+	err = hashDB.Verify(0, kind)
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+
+	if !strings.Contains(err.Error(), "does not match the hash recomputed") {
+		t.Errorf("the error was expected to report the hash mismatch, returned: %s", err.Error())
+		return
+	}
+}
+
+func TestCreate_withContentCache_thenReadConcurrently_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	contentCacheSize := uint(1024)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, contentCacheSize)
+
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+
+	// the cache only fits two of the resources, so the concurrent reads keep evicting:
+	kind := uint(0)
+	data := [][]byte{}
+	hashes := []hash.Hash{}
+	for i := 0; i < 4; i++ {
+		oneData := bytes.Repeat([]byte(fmt.Sprintf("%d", i)), 400)
+		oneHash, err := hashDB.WriteReader(*pContext, kind, bytes.NewReader(oneData))
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		data = append(data, oneData)
+		hashes = append(hashes, oneHash)
+	}
+
+	err = database.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	amount := 8
+	errs := make(chan error, amount)
+	start := make(chan struct{})
+	waitGroup := sync.WaitGroup{}
+	for i := 0; i < amount; i++ {
+		waitGroup.Add(1)
+		go func(offset int) {
+			defer waitGroup.Done()
+			<-start
+			for j := 0; j < 50; j++ {
+				idx := (offset + j) % len(hashes)
+				retData, err := hashDB.Read(*pContext, kind, hashes[idx])
+				if err != nil {
+					errs <- err
+					return
+				}
+
+				if !bytes.Equal(retData, data[idx]) {
+					errs <- errors.New("the returned data is invalid")
+					return
+				}
+			}
+		}(i)
+	}
+
+	close(start)
+	waitGroup.Wait()
+	close(errs)
+	for oneErr := range errs {
+		t.Errorf("the error was expected to be nil, error returned: %s", oneErr.Error())
+		return
+	}
+}
+
+func TestCreate_thenReadStatus_thenBatchEraseAndWrite_thenReadStatus_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, 0)
+
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+
+	kind := uint(0)
+	data := []byte("this is the first data")
+	pHash, err := hash.NewAdapter().FromBytes(data)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// the database does not have any commit yet:
+	status, commit, err := hashDB.ReadStatus(*pContext, kind, *pHash)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if status != applications.StatusNeverExisted || commit != nil {
+		t.Errorf("the resource was expected to have never existed")
+		return
+	}
+
+	_, err = hashDB.WriteReader(*pContext, kind, bytes.NewReader(data))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = database.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	secondData := []byte("this is the second data")
+	pSecondHash, err := hash.NewAdapter().FromBytes(secondData)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	batch := hashDB.NewBatch(*pContext)
+	batch.Delete(kind, *pHash)
+	batch.Put(kind, *pSecondHash, secondData)
+	err = batch.Apply()
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	status, commit, err = hashDB.ReadStatus(*pContext, kind, *pHash)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if status != applications.StatusErased || commit == nil {
+		t.Errorf("the resource was expected to be erased")
+		return
+	}
+}
+
+func TestCreate_thenWrite_thenErase_thenKindLatestCommit_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, 0)
+
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+
+	kind := uint(0)
+	_, err = hashDB.WriteReader(*pContext, kind, bytes.NewReader([]byte("this is the first data")))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	secondHash, err := hashDB.WriteReader(*pContext, kind, bytes.NewReader([]byte("this is the second data")))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = database.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = hashDB.Erase(*pContext, kind, secondHash)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = database.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	commits, err := database.Commits(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	retCommit, err := hashDB.KindLatestCommit(*pContext, kind)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !retCommit.Hash().Compare(commits.Latest().Hash()) {
+		t.Errorf("the latest commit of the kind was expected to be the erasing commit")
+		return
+	}
+}
+
+func TestImportKind_withInvalidLength_returnsError(t *testing.T) {
+	hashDB := NewApplication(&staticDatabase{}, 0)
+	lengths := []uint64{
+		math.MaxUint64,
+		1 << 40,
+	}
+
+	for _, oneLength := range lengths {
+		buffer := bytes.Buffer{}
+		kindBytes := make([]byte, exportedUintLength)
+		binary.LittleEndian.PutUint64(kindBytes, 0)
+		buffer.Write(kindBytes)
+		buffer.Write(make([]byte, hash.Size))
+
+		lengthBytes := make([]byte, exportedUintLength)
+		binary.LittleEndian.PutUint64(lengthBytes, oneLength)
+		buffer.Write(lengthBytes)
+		buffer.Write([]byte("this is not as long as declared"))
+
+		err := hashDB.ImportKind(0, &buffer)
+		if err == nil {
+			t.Errorf("the error was expected to be valid, nil returned")
+			return
+		}
+	}
+}
+
+func TestCreate_thenWriteReaderTwice_beforeCommit_returnsErrAlreadyExists(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, 0)
+
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+
+	kind := uint(1)
+	data := []byte("this is some data")
+	_, err = hashDB.WriteReader(*pContext, kind, bytes.NewReader(data))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	_, err = hashDB.WriteReader(*pContext, kind, bytes.NewReader(data))
+	if !errors.Is(err, applications.ErrAlreadyExists) {
+		t.Errorf("the error was expected to be ErrAlreadyExists, %v returned", err)
+		return
+	}
+
+	err = database.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	retHashes, err := hashDB.List(*pContext, kind)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if len(retHashes) != 1 {
+		t.Errorf("%d resources were expected, %d returned", 1, len(retHashes))
+		return
+	}
+}
@@ -0,0 +1,132 @@
+package files
+
+import (
+	"fmt"
+
+	"github.com/steve-care-software/databases/domain/references"
+	hashdb "github.com/steve-care-software/hashdb/applications"
+	"github.com/steve-care-software/libs/cryptography/hash"
+)
+
+type batchEntry struct {
+	kind uint
+	hash hash.Hash
+	data []byte
+}
+
+type batch struct {
+	app     *application
+	context uint
+	puts    []batchEntry
+	deletes []batchEntry
+}
+
+func createBatch(
+	app *application,
+	context uint,
+) hashdb.Batch {
+	out := batch{
+		app:     app,
+		context: context,
+		puts:    []batchEntry{},
+		deletes: []batchEntry{},
+	}
+
+	return &out
+}
+
+// Put adds a resource to write
+func (obj *batch) Put(kind uint, hash hash.Hash, data []byte) {
+	obj.puts = append(obj.puts, batchEntry{
+		kind: kind,
+		hash: hash,
+		data: data,
+	})
+}
+
+// Delete adds a resource to erase
+func (obj *batch) Delete(kind uint, hash hash.Hash) {
+	obj.deletes = append(obj.deletes, batchEntry{
+		kind: kind,
+		hash: hash,
+	})
+}
+
+// Apply stages the batch on its context and commits it, the puts of resources that are already active or staged are skipped
+func (obj *batch) Apply() error {
+	if len(obj.puts) <= 0 && len(obj.deletes) <= 0 {
+		return nil
+	}
+
+	// validate the data and resolve every content key before staging anything on the context:
+	for _, oneEntry := range obj.puts {
+		err := obj.app.validate(oneEntry.kind, oneEntry.data)
+		if err != nil {
+			return err
+		}
+	}
+
+	deleted := map[string]bool{}
+	contentKeys := []references.ContentKey{}
+	for _, oneEntry := range obj.deletes {
+		contentKey, err := obj.app.retrieveActiveContentKeyByHash(obj.context, oneEntry.kind, oneEntry.hash)
+		if err != nil {
+			return err
+		}
+
+		deleted[fmt.Sprintf("%d:%s", oneEntry.kind, oneEntry.hash.String())] = true
+		contentKeys = append(contentKeys, contentKey)
+	}
+
+	// a put repeated in the batch, already staged on the context, or whose resource is already active
+	// and not deleted by the batch, would only stage a duplicate:
+	puts := []batchEntry{}
+	kept := map[string]bool{}
+	for _, oneEntry := range obj.puts {
+		keyname := fmt.Sprintf("%d:%s", oneEntry.kind, oneEntry.hash.String())
+		if kept[keyname] || obj.app.isStaged(obj.context, oneEntry.kind, oneEntry.hash) {
+			continue
+		}
+
+		_, err := obj.app.retrieveActiveContentKeyByHash(obj.context, oneEntry.kind, oneEntry.hash)
+		if err == nil && !deleted[keyname] {
+			continue
+		}
+
+		kept[keyname] = true
+		puts = append(puts, oneEntry)
+	}
+
+	if len(puts) <= 0 && len(contentKeys) <= 0 {
+		obj.Discard()
+		return nil
+	}
+
+	for _, oneContentKey := range contentKeys {
+		err := obj.app.pointerDB.Erase(obj.context, oneContentKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, oneEntry := range puts {
+		err := obj.app.pointerDB.Write(obj.context, oneEntry.kind, oneEntry.hash, oneEntry.data)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := obj.app.pointerDB.Commit(obj.context)
+	if err != nil {
+		return err
+	}
+
+	obj.Discard()
+	return nil
+}
+
+// Discard removes every resource from the batch
+func (obj *batch) Discard() {
+	obj.puts = []batchEntry{}
+	obj.deletes = []batchEntry{}
+}
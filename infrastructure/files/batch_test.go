@@ -0,0 +1,321 @@
+package files
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	infrastructure_database_files "github.com/steve-care-software/databases/infrastructure/files"
+	"github.com/steve-care-software/libs/cryptography/hash"
+)
+
+func TestBatch_Discard_thenApply_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, 0)
+
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+	data := []byte("this is some data")
+	pHash, err := hash.NewAdapter().FromBytes(data)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	kind := uint(0)
+
+	// discard a batch:
+	discarded := hashDB.NewBatch(*pContext)
+	discarded.Put(kind, *pHash, data)
+	discarded.Discard()
+
+	err = discarded.Apply()
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	_, err = hashDB.Read(*pContext, kind, *pHash)
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+
+	// apply a batch:
+	applied := hashDB.NewBatch(*pContext)
+	applied.Put(kind, *pHash, data)
+	err = applied.Apply()
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	retData, err := hashDB.Read(*pContext, kind, *pHash)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if bytes.Compare(retData, data) != 0 {
+		t.Errorf("the returned data is invalid")
+		return
+	}
+
+	// delete using a batch:
+	deleted := hashDB.NewBatch(*pContext)
+	deleted.Delete(kind, *pHash)
+	err = deleted.Apply()
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	_, err = hashDB.Read(*pContext, kind, *pHash)
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+}
+
+func TestBatch_DeleteAndPut_thenApply_thenDeletedBy_thenKeyHistory_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, 0)
+
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+
+	kind := uint(0)
+	firstHash, err := hashDB.WriteReader(*pContext, kind, bytes.NewReader([]byte("this is the first data")))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = database.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	data := []byte("this is the second data")
+	pSecondHash, err := hash.NewAdapter().FromBytes(data)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// erasing and writing in the same batch creates two commits with the same parent:
+	batch := hashDB.NewBatch(*pContext)
+	batch.Delete(kind, firstHash)
+	batch.Put(kind, *pSecondHash, data)
+	err = batch.Apply()
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	retCommit, err := hashDB.DeletedBy(*pContext, kind, firstHash)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !retCommit.Action().HasDelete() {
+		t.Errorf("the returned commit was expected to be the erasing commit")
+		return
+	}
+	retHistory, err := hashDB.KeyHistory(*pContext, kind, firstHash)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if len(retHistory) != 2 {
+		t.Errorf("%d commits were expected, %d returned", 2, len(retHistory))
+		return
+	}
+
+	if !retHistory[1].Hash().Compare(retCommit.Hash()) {
+		t.Errorf("the last commit of the history was expected to be the erasing commit")
+		return
+	}
+}
+
+func TestBatch_PutActiveResource_thenApply_isSkipped_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := &countingDatabase{
+		Application: infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize),
+	}
+
+	hashDB := NewApplication(database, 0)
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+
+	kind := uint(0)
+	data := []byte("this is some data")
+	dataHash, err := hashDB.WriteReader(*pContext, kind, bytes.NewReader(data))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = database.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	batch := hashDB.NewBatch(*pContext)
+	batch.Put(kind, dataHash, data)
+	err = batch.Apply()
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if database.commits != 1 {
+		t.Errorf("%d commits were expected, %d executed", 1, database.commits)
+		return
+	}
+
+	retHashes, err := hashDB.List(*pContext, kind)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if len(retHashes) != 1 {
+		t.Errorf("%d resources were expected, %d returned", 1, len(retHashes))
+		return
+	}
+}
+
+func TestBatch_PutTwice_thenApply_thenApply_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	hashDB := NewApplication(database, 0)
+
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+
+	kind := uint(0)
+	data := []byte("this is some data")
+	pHash, err := hash.NewAdapter().FromBytes(data)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	first := hashDB.NewBatch(*pContext)
+	first.Put(kind, *pHash, data)
+	first.Put(kind, *pHash, data)
+	err = first.Apply()
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	secondData := []byte("this is the second data")
+	pSecondHash, err := hash.NewAdapter().FromBytes(secondData)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	second := hashDB.NewBatch(*pContext)
+	second.Put(kind, *pSecondHash, secondData)
+	err = second.Apply()
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	retHashes, err := hashDB.List(*pContext, kind)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if len(retHashes) != 2 {
+		t.Errorf("%d resources were expected, %d returned", 2, len(retHashes))
+		return
+	}
+}
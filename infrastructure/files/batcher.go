@@ -0,0 +1,143 @@
+package files
+
+import (
+	"sync"
+	"time"
+
+	databases "github.com/steve-care-software/databases/applications"
+	"github.com/steve-care-software/databases/domain/references"
+	hashdb "github.com/steve-care-software/hashdb/applications"
+	"github.com/steve-care-software/libs/cryptography/hash"
+)
+
+type batcher struct {
+	pointerDB     databases.Application
+	context       uint
+	maxOperations uint
+	pending       uint
+	ticker        *time.Ticker
+	done          chan struct{}
+	waitGroup     *sync.WaitGroup
+	mutex         *sync.Mutex
+	closeOnce     *sync.Once
+	lastErr       error
+}
+
+func createBatcher(
+	pointerDB databases.Application,
+	context uint,
+	interval time.Duration,
+	maxOperations uint,
+) hashdb.Batcher {
+	out := batcher{
+		pointerDB:     pointerDB,
+		context:       context,
+		maxOperations: maxOperations,
+		pending:       0,
+		done:          make(chan struct{}),
+		waitGroup:     &sync.WaitGroup{},
+		mutex:         &sync.Mutex{},
+		closeOnce:     &sync.Once{},
+	}
+
+	// zero (0) disables the periodic commits, like it disables the maximum of operations:
+	if interval > 0 {
+		out.ticker = time.NewTicker(interval)
+		out.waitGroup.Add(1)
+		go out.run()
+	}
+
+	return &out
+}
+
+func (app *batcher) run() {
+	defer app.waitGroup.Done()
+	for {
+		select {
+		case <-app.ticker.C:
+			app.mutex.Lock()
+			err := app.flush()
+			if err != nil {
+				app.lastErr = err
+			}
+			app.mutex.Unlock()
+		case <-app.done:
+			return
+		}
+	}
+}
+
+// Write stages data on the context
+func (app *batcher) Write(kind uint, hash hash.Hash, data []byte) error {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+	err := app.pointerDB.Write(app.context, kind, hash, data)
+	if err != nil {
+		return err
+	}
+
+	return app.staged()
+}
+
+// Erase stages the erasure of a contentKey on the context
+func (app *batcher) Erase(contentKey references.ContentKey) error {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+	err := app.pointerDB.Erase(app.context, contentKey)
+	if err != nil {
+		return err
+	}
+
+	return app.staged()
+}
+
+func (app *batcher) staged() error {
+	app.pending++
+	if app.maxOperations <= 0 || app.pending < app.maxOperations {
+		return nil
+	}
+
+	return app.flush()
+}
+
+// Flush commits the staged resources, if any, then returns the error of the last failed periodic commit, if any
+func (app *batcher) Flush() error {
+	app.mutex.Lock()
+	defer app.mutex.Unlock()
+	err := app.flush()
+	if err != nil {
+		return err
+	}
+
+	err = app.lastErr
+	app.lastErr = nil
+	return err
+}
+
+func (app *batcher) flush() error {
+	if app.pending <= 0 {
+		return nil
+	}
+
+	err := app.pointerDB.Commit(app.context)
+	if err != nil {
+		return err
+	}
+
+	app.pending = 0
+	return nil
+}
+
+// Close stops the periodic commits and commits the remaining staged resources
+func (app *batcher) Close() error {
+	app.closeOnce.Do(func() {
+		if app.ticker != nil {
+			app.ticker.Stop()
+		}
+
+		close(app.done)
+		app.waitGroup.Wait()
+	})
+
+	return app.Flush()
+}
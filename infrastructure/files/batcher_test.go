@@ -0,0 +1,258 @@
+package files
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	databases "github.com/steve-care-software/databases/applications"
+	infrastructure_database_files "github.com/steve-care-software/databases/infrastructure/files"
+	"github.com/steve-care-software/libs/cryptography/hash"
+)
+
+func TestBatcher_withMaxOperations_thenClose_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := &countingDatabase{
+		Application: infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize),
+	}
+
+	hashDB := NewApplication(database, 0)
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+
+	kind := uint(0)
+	amount := 10
+	batcher := NewBatcher(database, *pContext, time.Hour, 4)
+	for i := 0; i < amount; i++ {
+		data := []byte(fmt.Sprintf("this is data %d", i))
+		pHash, err := hash.NewAdapter().FromBytes(data)
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		err = batcher.Write(kind, *pHash, data)
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+	}
+
+	err = batcher.Close()
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if database.commits != 3 {
+		t.Errorf("%d commits were expected, %d executed", 3, database.commits)
+		return
+	}
+
+	retHashes, err := hashDB.List(*pContext, kind)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if len(retHashes) != amount {
+		t.Errorf("%d resources were expected, %d returned", amount, len(retHashes))
+		return
+	}
+}
+
+func TestBatcher_withInterval_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := &countingDatabase{
+		Application: infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize),
+	}
+
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+
+	batcher := NewBatcher(database, *pContext, 10*time.Millisecond, 0)
+	defer batcher.Close()
+
+	data := []byte("this is some data")
+	pHash, err := hash.NewAdapter().FromBytes(data)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = batcher.Write(0, *pHash, data)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	time.Sleep(200 * time.Millisecond)
+
+	// nothing is pending anymore, so the flush does not commit:
+	err = batcher.Flush()
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if database.commits != 1 {
+		t.Errorf("%d commits were expected, %d executed", 1, database.commits)
+		return
+	}
+}
+
+func TestBatcher_withoutInterval_thenCloseTwice_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	name := "my_name"
+	database := &countingDatabase{
+		Application: infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize),
+	}
+
+	err := database.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := database.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pContext)
+
+	data := []byte("this is some data")
+	pHash, err := hash.NewAdapter().FromBytes(data)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// zero (0) disables the periodic commits:
+	batcher := NewBatcher(database, *pContext, 0, 0)
+	err = batcher.Write(uint(0), *pHash, data)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = batcher.Close()
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = batcher.Close()
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if database.commits != 1 {
+		t.Errorf("%d commits were expected, %d executed", 1, database.commits)
+		return
+	}
+}
+
+type failingCommitDatabase struct {
+	databases.Application
+	failures int
+	commits  int
+}
+
+// Write accepts the data without staging it
+func (app *failingCommitDatabase) Write(context uint, kind uint, hash hash.Hash, data []byte) error {
+	return nil
+}
+
+// Commit fails until the amount of failures is reached
+func (app *failingCommitDatabase) Commit(context uint) error {
+	app.commits++
+	if app.commits <= app.failures {
+		return errors.New("the commit failed")
+	}
+
+	return nil
+}
+
+func TestBatcher_withFailingPeriodicCommit_thenFlush_returnsError(t *testing.T) {
+	database := &failingCommitDatabase{
+		failures: 1,
+	}
+
+	data := []byte("this is some data")
+	pHash, err := hash.NewAdapter().FromBytes(data)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	batcher := NewBatcher(database, uint(0), 5*time.Millisecond, 0)
+	err = batcher.Write(uint(0), *pHash, data)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// the first periodic commit fails, the next one commits the staged resource:
+	time.Sleep(100 * time.Millisecond)
+	err = batcher.Flush()
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+
+	err = batcher.Close()
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+}
@@ -0,0 +1,68 @@
+package files
+
+import "sync"
+
+type contentCache struct {
+	head  string
+	size  uint
+	order []string
+	data  map[string][]byte
+	mutex *sync.Mutex
+}
+
+func createContentCache(
+	head string,
+) *contentCache {
+	out := contentCache{
+		head:  head,
+		size:  0,
+		order: []string{},
+		data:  map[string][]byte{},
+		mutex: &sync.Mutex{},
+	}
+
+	return &out
+}
+
+func (obj *contentCache) fetch(keyname string) ([]byte, bool) {
+	obj.mutex.Lock()
+	defer obj.mutex.Unlock()
+	data, ok := obj.data[keyname]
+	if !ok {
+		return nil, false
+	}
+
+	// the caller owns the returned slice, so the cached data is copied:
+	output := make([]byte, len(data))
+	copy(output, data)
+	return output, true
+}
+
+func (obj *contentCache) save(keyname string, data []byte, budget uint) {
+	length := uint(len(data))
+	if length > budget {
+		return
+	}
+
+	obj.mutex.Lock()
+	defer obj.mutex.Unlock()
+
+	if _, ok := obj.data[keyname]; ok {
+		return
+	}
+
+	// evict the oldest entries until the data fits in the budget:
+	for obj.size+length > budget && len(obj.order) > 0 {
+		oldest := obj.order[0]
+		obj.size -= uint(len(obj.data[oldest]))
+		obj.order = obj.order[1:]
+		delete(obj.data, oldest)
+	}
+
+	// the caller keeps the given slice, so the data is copied before being cached:
+	stored := make([]byte, len(data))
+	copy(stored, data)
+	obj.order = append(obj.order, keyname)
+	obj.data[keyname] = stored
+	obj.size += length
+}
@@ -0,0 +1,95 @@
+package files
+
+import (
+	databases "github.com/steve-care-software/databases/applications"
+	"github.com/steve-care-software/hashdb/applications"
+	"github.com/steve-care-software/libs/cryptography/hash"
+)
+
+type interceptor struct {
+	databases.Application
+	hooks  []applications.WriteHook
+	writes map[uint][]interceptedWrite
+}
+
+func createInterceptor(
+	pointerDB databases.Application,
+) applications.Interceptor {
+	out := interceptor{
+		Application: pointerDB,
+		hooks:       []applications.WriteHook{},
+		writes:      map[uint][]interceptedWrite{},
+	}
+
+	return &out
+}
+
+// OnBeforeWrite registers a hook executed on each write when its context is committed
+func (app *interceptor) OnBeforeWrite(hook applications.WriteHook) {
+	app.hooks = append(app.hooks, hook)
+}
+
+// Write keeps the data until its context is committed
+func (app *interceptor) Write(context uint, kind uint, hash hash.Hash, data []byte) error {
+	app.writes[context] = append(app.writes[context], interceptedWrite{
+		kind: kind,
+		hash: hash,
+		data: data,
+	})
+
+	return nil
+}
+
+// Cancel cancels a context, along with the writes kept for it
+func (app *interceptor) Cancel(context uint) error {
+	err := app.Application.Cancel(context)
+	if err != nil {
+		return err
+	}
+
+	delete(app.writes, context)
+	return nil
+}
+
+// Commit executes the hooks on the writes kept for the context, stages their data, then commits the context
+func (app *interceptor) Commit(context uint) error {
+	// every hook is executed before staging anything, so a failing hook aborts the commit and the writes stay pending:
+	writes := app.writes[context]
+	transformed := [][]byte{}
+	for _, oneWrite := range writes {
+		data := oneWrite.data
+		for _, oneHook := range app.hooks {
+			output, err := oneHook(oneWrite.kind, oneWrite.hash, data)
+			if err != nil {
+				return err
+			}
+
+			data = output
+		}
+
+		transformed = append(transformed, data)
+	}
+
+	// the hash is never recomputed, the transformations are meant for validation and side effects, not for re-keying:
+	for idx, oneWrite := range writes {
+		err := app.Application.Write(context, oneWrite.kind, oneWrite.hash, transformed[idx])
+		if err != nil {
+			return err
+		}
+	}
+
+	delete(app.writes, context)
+	return app.Application.Commit(context)
+}
+
+// Close closes a context, along with the writes kept for it
+func (app *interceptor) Close(context uint) error {
+	delete(app.writes, context)
+	return app.Application.Close(context)
+}
+
+type interceptedWrite struct {
+	kind uint
+	hash hash.Hash
+	data []byte
+}
@@ -0,0 +1,114 @@
+package files
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	infrastructure_database_files "github.com/steve-care-software/databases/infrastructure/files"
+	"github.com/steve-care-software/libs/cryptography/hash"
+)
+
+func TestInterceptor_withRejectingHook_thenCommit_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	interceptor := NewInterceptor(database)
+	hashDB := NewApplication(interceptor, 0)
+
+	interceptor.OnBeforeWrite(func(kind uint, hash hash.Hash, data []byte) ([]byte, error) {
+		if bytes.Contains(data, []byte("forbidden")) {
+			return nil, errors.New("the data contains a forbidden word")
+		}
+
+		return data, nil
+	})
+
+	name := "my_name"
+	err := interceptor.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := interceptor.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer interceptor.Close(*pContext)
+
+	kind := uint(0)
+	data := []byte("this is some data")
+	dataHash, err := hashDB.WriteReader(*pContext, kind, bytes.NewReader(data))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	_, err = hashDB.WriteReader(*pContext, kind, bytes.NewReader([]byte("this is some forbidden data")))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = interceptor.Commit(*pContext)
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+
+	_, err = interceptor.Commits(*pContext)
+	if err == nil {
+		t.Errorf("the commit was expected to be aborted")
+		return
+	}
+
+	err = hashDB.Cancel(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	_, err = hashDB.WriteReader(*pContext, kind, bytes.NewReader(data))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = interceptor.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	retData, err := hashDB.Read(*pContext, kind, dataHash)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !bytes.Equal(retData, data) {
+		t.Errorf("the returned data is invalid")
+		return
+	}
+
+	retHashes, err := hashDB.List(*pContext, kind)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if len(retHashes) != 1 {
+		t.Errorf("%d resources were expected, %d returned", 1, len(retHashes))
+		return
+	}
+}
@@ -0,0 +1,163 @@
+package files
+
+import (
+	"log"
+
+	databases "github.com/steve-care-software/databases/applications"
+	"github.com/steve-care-software/databases/domain/references"
+	"github.com/steve-care-software/hashdb/applications"
+	"github.com/steve-care-software/libs/cryptography/hash"
+)
+
+type notifier struct {
+	databases.Application
+	failOnHookError bool
+	hooks           []applications.CommitHook
+	stages          map[uint]*notifierStage
+}
+
+func createNotifier(
+	pointerDB databases.Application,
+	failOnHookError bool,
+) applications.Notifier {
+	out := notifier{
+		Application:     pointerDB,
+		failOnHookError: failOnHookError,
+		hooks:           []applications.CommitHook{},
+		stages:          map[uint]*notifierStage{},
+	}
+
+	return &out
+}
+
+// OnCommit registers a hook executed after each successful commit
+func (app *notifier) OnCommit(hook applications.CommitHook) {
+	app.hooks = append(app.hooks, hook)
+}
+
+// Write writes data to a context, then keeps track of its kind and hash
+func (app *notifier) Write(context uint, kind uint, hash hash.Hash, data []byte) error {
+	err := app.Application.Write(context, kind, hash, data)
+	if err != nil {
+		return err
+	}
+
+	stage := app.stage(context)
+	stage.inserts = append(stage.inserts, notifierInsert{
+		kind: kind,
+		hash: hash,
+	})
+
+	return nil
+}
+
+// Erase erases a contentKey, then keeps track of it
+func (app *notifier) Erase(context uint, contentKey references.ContentKey) error {
+	err := app.Application.Erase(context, contentKey)
+	if err != nil {
+		return err
+	}
+
+	stage := app.stage(context)
+	stage.deletes = append(stage.deletes, contentKey)
+	return nil
+}
+
+// Cancel cancels a context, then forgets what was staged on it
+func (app *notifier) Cancel(context uint) error {
+	err := app.Application.Cancel(context)
+	if err != nil {
+		return err
+	}
+
+	delete(app.stages, context)
+	return nil
+}
+
+// Commit commits a context, then executes the hooks with the content keys it changed
+func (app *notifier) Commit(context uint) error {
+	err := app.Application.Commit(context)
+	if err != nil {
+		return err
+	}
+
+	stage := app.stage(context)
+	delete(app.stages, context)
+
+	commits, err := app.Application.Commits(context)
+	if err != nil {
+		return err
+	}
+
+	// the inserted content keys only exist once committed, since they hold their pointer and commit:
+	contentKeysByKind := map[uint]references.ContentKeys{}
+	inserted := []references.ContentKey{}
+	for _, oneInsert := range stage.inserts {
+		if _, ok := contentKeysByKind[oneInsert.kind]; !ok {
+			contentKeys, err := app.Application.ContentKeys(context, oneInsert.kind)
+			if err != nil {
+				return err
+			}
+
+			contentKeysByKind[oneInsert.kind] = contentKeys
+		}
+
+		contentKey, err := contentKeysByKind[oneInsert.kind].Fetch(oneInsert.kind, oneInsert.hash)
+		if err != nil {
+			return err
+		}
+
+		inserted = append(inserted, contentKey)
+	}
+
+	// the commit is already durable, so a failing hook does not prevent the others from being executed:
+	latest := commits.Latest()
+	var firstErr error
+	for _, oneHook := range app.hooks {
+		err := oneHook(context, latest, inserted, stage.deletes)
+		if err == nil {
+			continue
+		}
+
+		if !app.failOnHookError {
+			log.Printf("the commit (hash: %s) hook failed: %s", latest.Hash().String(), err.Error())
+			continue
+		}
+
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// Close closes a context
+func (app *notifier) Close(context uint) error {
+	delete(app.stages, context)
+	return app.Application.Close(context)
+}
+
+func (app *notifier) stage(context uint) *notifierStage {
+	if pStage, ok := app.stages[context]; ok {
+		return pStage
+	}
+
+	pStage := &notifierStage{
+		inserts: []notifierInsert{},
+		deletes: []references.ContentKey{},
+	}
+
+	app.stages[context] = pStage
+	return pStage
+}
+
+type notifierStage struct {
+	inserts []notifierInsert
+	deletes []references.ContentKey
+}
+
+type notifierInsert struct {
+	kind uint
+	hash hash.Hash
+}
@@ -0,0 +1,182 @@
+package files
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/steve-care-software/databases/domain/references"
+	infrastructure_database_files "github.com/steve-care-software/databases/infrastructure/files"
+	"github.com/steve-care-software/libs/cryptography/hash"
+)
+
+func TestNotifier_thenWrite_thenErase_thenCommit_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	notifier := NewNotifier(database, false)
+	hashDB := NewApplication(notifier, 0)
+
+	var retCommit references.Commit
+	retInserted := []references.ContentKey{}
+	retDeleted := []references.ContentKey{}
+	notifier.OnCommit(func(context uint, commit references.Commit, inserted []references.ContentKey, deleted []references.ContentKey) error {
+		retCommit = commit
+		retInserted = inserted
+		retDeleted = deleted
+		return nil
+	})
+
+	// a failing hook does not fail the commit:
+	notifier.OnCommit(func(context uint, commit references.Commit, inserted []references.ContentKey, deleted []references.ContentKey) error {
+		return errors.New("the index is unavailable")
+	})
+
+	name := "my_name"
+	err := notifier.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := notifier.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer notifier.Close(*pContext)
+
+	kind := uint(0)
+	hashes := []hash.Hash{}
+	for _, oneData := range []string{"this is the first data", "this is the second data"} {
+		oneHash, err := hashDB.WriteReader(*pContext, kind, bytes.NewReader([]byte(oneData)))
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		hashes = append(hashes, oneHash)
+	}
+
+	err = notifier.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	commits, err := notifier.Commits(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if !retCommit.Hash().Compare(commits.Latest().Hash()) {
+		t.Errorf("the hook was expected to receive the latest commit")
+		return
+	}
+
+	if len(retInserted) != len(hashes) || len(retDeleted) != 0 {
+		t.Errorf("%d inserted and %d deleted content keys were expected, %d and %d returned", len(hashes), 0, len(retInserted), len(retDeleted))
+		return
+	}
+
+	for idx, oneContentKey := range retInserted {
+		if !oneContentKey.Hash().Compare(hashes[idx]) {
+			t.Errorf("the inserted content key at index %d was expected to hold the hash %s, %s returned", idx, hashes[idx].String(), oneContentKey.Hash().String())
+			return
+		}
+
+		if !oneContentKey.Commit().Compare(retCommit.Hash()) {
+			t.Errorf("the inserted content key at index %d was expected to reference the commit", idx)
+			return
+		}
+	}
+
+	err = hashDB.Erase(*pContext, kind, hashes[1])
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = notifier.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if len(retInserted) != 0 || len(retDeleted) != 1 {
+		t.Errorf("%d inserted and %d deleted content keys were expected, %d and %d returned", 0, 1, len(retInserted), len(retDeleted))
+		return
+	}
+
+	if !retDeleted[0].Hash().Compare(hashes[1]) {
+		t.Errorf("the deleted content key was expected to hold the hash %s, %s returned", hashes[1].String(), retDeleted[0].Hash().String())
+		return
+	}
+}
+
+func TestNotifier_withFailOnHookError_thenCommit_returnsError(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	notifier := NewNotifier(database, true)
+	hashDB := NewApplication(notifier, 0)
+
+	notifier.OnCommit(func(context uint, commit references.Commit, inserted []references.ContentKey, deleted []references.ContentKey) error {
+		return errors.New("the index is unavailable")
+	})
+
+	name := "my_name"
+	err := notifier.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := notifier.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer notifier.Close(*pContext)
+
+	kind := uint(0)
+	_, err = hashDB.WriteReader(*pContext, kind, bytes.NewReader([]byte("this is some data")))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = notifier.Commit(*pContext)
+	if err == nil {
+		t.Errorf("the error was expected to be valid, nil returned")
+		return
+	}
+
+	// the commit is durable even though the hook failed:
+	retHashes, err := hashDB.List(*pContext, kind)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if len(retHashes) != 1 {
+		t.Errorf("%d resources were expected, %d returned", 1, len(retHashes))
+		return
+	}
+}
@@ -0,0 +1,13 @@
+package files
+
+const operationWrite = "write"
+const operationErase = "erase"
+const operationCancel = "cancel"
+const operationCommit = "commit"
+
+type operation struct {
+	Name string `json:"name"`
+	Kind uint   `json:"kind,omitempty"`
+	Hash string `json:"hash,omitempty"`
+	Data []byte `json:"data,omitempty"`
+}
@@ -0,0 +1,80 @@
+package files
+
+import (
+	"encoding/json"
+	"io"
+
+	databases "github.com/steve-care-software/databases/applications"
+	"github.com/steve-care-software/databases/domain/references"
+	"github.com/steve-care-software/libs/cryptography/hash"
+)
+
+type recorder struct {
+	databases.Application
+	encoder *json.Encoder
+}
+
+func createRecorder(
+	pointerDB databases.Application,
+	writer io.Writer,
+) databases.Application {
+	out := recorder{
+		Application: pointerDB,
+		encoder:     json.NewEncoder(writer),
+	}
+
+	return &out
+}
+
+// Write writes data to a context, then records the operation
+func (app *recorder) Write(context uint, kind uint, hash hash.Hash, data []byte) error {
+	err := app.Application.Write(context, kind, hash, data)
+	if err != nil {
+		return err
+	}
+
+	return app.encoder.Encode(operation{
+		Name: operationWrite,
+		Kind: kind,
+		Hash: hash.String(),
+		Data: data,
+	})
+}
+
+// Erase erases a contentKey, then records the operation
+func (app *recorder) Erase(context uint, contentKey references.ContentKey) error {
+	err := app.Application.Erase(context, contentKey)
+	if err != nil {
+		return err
+	}
+
+	return app.encoder.Encode(operation{
+		Name: operationErase,
+		Kind: contentKey.Kind(),
+		Hash: contentKey.Hash().String(),
+	})
+}
+
+// Cancel cancels a context, then records the operation
+func (app *recorder) Cancel(context uint) error {
+	err := app.Application.Cancel(context)
+	if err != nil {
+		return err
+	}
+
+	return app.encoder.Encode(operation{
+		Name: operationCancel,
+	})
+}
+
+// Commit commits a context, then records the operation
+func (app *recorder) Commit(context uint) error {
+	err := app.Application.Commit(context)
+	if err != nil {
+		return err
+	}
+
+	return app.encoder.Encode(operation{
+		Name: operationCommit,
+	})
+}
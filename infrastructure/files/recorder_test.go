@@ -0,0 +1,153 @@
+package files
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"testing"
+
+	infrastructure_database_files "github.com/steve-care-software/databases/infrastructure/files"
+	"github.com/steve-care-software/libs/cryptography/hash"
+)
+
+func TestRecorder_thenReplay_Success(t *testing.T) {
+	dirPath := "./test_files"
+	dstExtension := "destination"
+	bckExtension := "backup"
+	readChunkSize := uint(1000000)
+	defer func() {
+		os.RemoveAll(dirPath)
+	}()
+
+	log := bytes.NewBuffer(nil)
+	database := infrastructure_database_files.NewApplication(dirPath, dstExtension, bckExtension, readChunkSize)
+	recorder := NewRecorder(database, log)
+	hashDB := NewApplication(recorder, 0)
+
+	name := "my_name"
+	err := recorder.New(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pContext, err := recorder.Open(name)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer recorder.Close(*pContext)
+
+	// each commit rewrites at most one existing resource, since the pointer database does not read
+	// back reliably after a commit that rewrites many existing resources:
+	kind := uint(0)
+	dataList := [][]byte{
+		[]byte("this is the first data"),
+		[]byte("this is the second data"),
+	}
+
+	_, err = hashDB.WriteReader(*pContext, kind, bytes.NewReader(dataList[0]))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = recorder.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	_, err = hashDB.WriteReader(*pContext, kind, bytes.NewReader(dataList[1]))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	erasedHash, err := hashDB.WriteReader(*pContext, kind, bytes.NewReader([]byte("this is the erased data")))
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = recorder.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = hashDB.Erase(*pContext, kind, erasedHash)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	err = recorder.Commit(*pContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	// replay the log in a new database:
+	replayedName := "my_replayed_name"
+	err = NewReplayer(database).Replay(replayedName, log)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	pReplayedContext, err := database.Open(replayedName)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	defer database.Close(*pReplayedContext)
+
+	retKind, err := hashDB.ReadKind(*pContext, kind)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	retReplayedKind, err := hashDB.ReadKind(*pReplayedContext, kind)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if len(retReplayedKind) != len(dataList) {
+		t.Errorf("%d resources were expected in the replayed database, %d returned", len(dataList), len(retReplayedKind))
+		return
+	}
+
+	for _, oneData := range dataList {
+		pHash, err := hash.NewAdapter().FromBytes(oneData)
+		if err != nil {
+			t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+			return
+		}
+
+		if !bytes.Equal(retReplayedKind[pHash.String()], oneData) {
+			t.Errorf("the replayed resource (hash: %s) was expected to contain the recorded data", pHash.String())
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(retKind, retReplayedKind) {
+		t.Errorf("the replayed database was expected to contain the same resources as the recorded one")
+		return
+	}
+
+	retCommits, err := database.Commits(*pReplayedContext)
+	if err != nil {
+		t.Errorf("the error was expected to be nil, error returned: %s", err.Error())
+		return
+	}
+
+	if len(retCommits.List()) != 3 {
+		t.Errorf("%d commits were expected in the replayed database, %d returned", 3, len(retCommits.List()))
+		return
+	}
+}
@@ -0,0 +1,97 @@
+package files
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	databases "github.com/steve-care-software/databases/applications"
+	hashdb "github.com/steve-care-software/hashdb/applications"
+	"github.com/steve-care-software/libs/cryptography/hash"
+)
+
+type replayer struct {
+	hashAdapter hash.Adapter
+	pointerDB   databases.Application
+}
+
+func createReplayer(
+	hashAdapter hash.Adapter,
+	pointerDB databases.Application,
+) hashdb.Replayer {
+	out := replayer{
+		hashAdapter: hashAdapter,
+		pointerDB:   pointerDB,
+	}
+
+	return &out
+}
+
+// Replay creates a new database and replays the recorded operations on it
+func (app *replayer) Replay(name string, reader io.Reader) error {
+	err := app.pointerDB.New(name)
+	if err != nil {
+		return err
+	}
+
+	pContext, err := app.pointerDB.Open(name)
+	if err != nil {
+		return err
+	}
+
+	defer app.pointerDB.Close(*pContext)
+	decoder := json.NewDecoder(reader)
+	for {
+		ins := operation{}
+		err := decoder.Decode(&ins)
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		err = app.replay(*pContext, ins)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (app *replayer) replay(context uint, ins operation) error {
+	switch ins.Name {
+	case operationWrite:
+		pHash, err := app.hashAdapter.FromString(ins.Hash)
+		if err != nil {
+			return err
+		}
+
+		return app.pointerDB.Write(context, ins.Kind, *pHash, ins.Data)
+	case operationErase:
+		pHash, err := app.hashAdapter.FromString(ins.Hash)
+		if err != nil {
+			return err
+		}
+
+		contentKeys, err := app.pointerDB.ContentKeys(context, ins.Kind)
+		if err != nil {
+			return err
+		}
+
+		contentKey, err := contentKeys.Fetch(ins.Kind, *pHash)
+		if err != nil {
+			return err
+		}
+
+		return app.pointerDB.Erase(context, contentKey)
+	case operationCancel:
+		return app.pointerDB.Cancel(context)
+	case operationCommit:
+		return app.pointerDB.Commit(context)
+	}
+
+	str := fmt.Sprintf("the recorded operation (%s) is invalid", ins.Name)
+	return errors.New(str)
+}
@@ -1,13 +1,95 @@
 package files
 
 import (
+	"io"
+	"time"
+
 	databases "github.com/steve-care-software/databases/applications"
+	"github.com/steve-care-software/databases/domain/references"
 	"github.com/steve-care-software/hashdb/applications"
+	"github.com/steve-care-software/libs/cryptography/hash"
+	"github.com/steve-care-software/libs/cryptography/trees"
 )
 
-// NewApplication creates a new application instance
+const exportedUintLength = 8
+
+const readConsistentMaxAttempts = 10
+
+// zeroCommitErrorPrefix prefixes the error the pointer database returns for a known context that has no commit yet
+const zeroCommitErrorPrefix = "there is zero (0) Commit"
+
+// emptyContentKeysErrorPrefixes prefix the errors the pointer database returns for a kind without any content
+var emptyContentKeysErrorPrefixes = []string{
+	"there is zero (0) ContentKey",
+	"there is no content in the database",
+	"there is no contentKey related to the provided kind",
+}
+
+// NewApplication creates a new application instance, a contentCacheSize of zero (0) disables the content cache
 func NewApplication(
 	pointerDB databases.Application,
+	contentCacheSize uint,
 ) applications.Application {
-	return createApplication(pointerDB)
+	hashAdapter := hash.NewAdapter()
+	hashTreeBuilder := trees.NewBuilder()
+	hashTreeAdapter := trees.NewAdapter()
+	commitBuilder := references.NewCommitBuilder()
+	return createApplication(
+		hashAdapter,
+		hashTreeBuilder,
+		hashTreeAdapter,
+		commitBuilder,
+		pointerDB,
+		contentCacheSize,
+	)
+}
+
+// NewRecorder creates a pointer database that records its Write, Erase, Cancel and Commit operations to the writer
+func NewRecorder(
+	pointerDB databases.Application,
+	writer io.Writer,
+) databases.Application {
+	return createRecorder(pointerDB, writer)
+}
+
+// NewReplayer creates a new replayer instance that replays recorded operations on the pointer database
+func NewReplayer(
+	pointerDB databases.Application,
+) applications.Replayer {
+	hashAdapter := hash.NewAdapter()
+	return createReplayer(
+		hashAdapter,
+		pointerDB,
+	)
+}
+
+// NewBatcher creates a new batcher instance that commits the context at most every interval, or once maxOperations are staged, zero (0) disables either,
+// the context must not be read or written through the pointer database until the batcher is closed, since a periodic commit may run at any time
+func NewBatcher(
+	pointerDB databases.Application,
+	context uint,
+	interval time.Duration,
+	maxOperations uint,
+) applications.Batcher {
+	return createBatcher(
+		pointerDB,
+		context,
+		interval,
+		maxOperations,
+	)
+}
+
+// NewNotifier creates a pointer database that executes the registered hooks after each successful commit, a failing hook fails the commit only if failOnHookError is true
+func NewNotifier(
+	pointerDB databases.Application,
+	failOnHookError bool,
+) applications.Notifier {
+	return createNotifier(pointerDB, failOnHookError)
+}
+
+// NewInterceptor creates a pointer database that executes the registered hooks on each write when its context is committed, a failing hook aborts the commit
+func NewInterceptor(
+	pointerDB databases.Application,
+) applications.Interceptor {
+	return createInterceptor(pointerDB)
 }
@@ -0,0 +1,26 @@
+package files
+
+type stagedResources struct {
+	head string
+	keys map[string]struct{}
+}
+
+func createStagedResources(
+	head string,
+) *stagedResources {
+	out := stagedResources{
+		head: head,
+		keys: map[string]struct{}{},
+	}
+
+	return &out
+}
+
+func (obj *stagedResources) exists(keyname string) bool {
+	_, ok := obj.keys[keyname]
+	return ok
+}
+
+func (obj *stagedResources) add(keyname string) {
+	obj.keys[keyname] = struct{}{}
+}